@@ -0,0 +1,156 @@
+package dmidecode
+
+import (
+	"context"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// dmidecode -t 22
+// 没有设置Manufacture Date, Serial Number, Chemistry时使用SBDS(Smart Battery Data Specification)的对应字段
+type PortableBattery struct {
+	Handle  Handle            // DMI结构的句柄
+	DMIType int               // DMI type, dmidecode -q 的输出中为-1
+	Extra   map[string]string // 没有对应字段的key, 列表值用换行连接
+	Raw     map[string]string // 被替换的占位符和不在取值表中的枚举的原始值
+	//Location: Front
+	Location string `dmi:"Location,normalize"`
+	//Manufacturer: LGC
+	Manufacturer         string   `dmi:"Manufacturer,normalize"`
+	ManufacturerOptional Optional `dmi:"Manufacturer,normalize"`
+	//Manufacture Date: 02/13/2014 或 SBDS Manufacture Date: 2014-02-13
+	ManufactureDate string      `dmi:"Manufacture Date|SBDS Manufacture Date,normalize"`
+	Manufactured    BatteryDate `dmi:"Manufacture Date|SBDS Manufacture Date"`
+	//Serial Number: 1234 或 SBDS Serial Number: 0431
+	SerialNumber         string   `dmi:"Serial Number|SBDS Serial Number,normalize"`
+	SerialNumberOptional Optional `dmi:"Serial Number|SBDS Serial Number,normalize"`
+	//Name: 45N1011
+	Name string `dmi:"Name,normalize"`
+	//Chemistry: Lithium Ion 或 SBDS Chemistry: LION
+	Chemistry string `dmi:"Chemistry|SBDS Chemistry,normalize"`
+	//Design Capacity: 56160 mWh, 已经乘以Design Capacity Multiplier
+	DesignCapacity    string `dmi:"Design Capacity"`
+	DesignCapacityMWh int    `dmi:"Design Capacity"`
+	// Design Capacity Multiplier, dmidecode不输出, 只有读取SMBIOS表时可以得到, 未知时为0
+	DesignCapacityMultiplier int
+	//Design Voltage: 10800 mV
+	DesignVoltage      string  `dmi:"Design Voltage"`
+	DesignVoltageVolts Voltage `dmi:"Design Voltage"`
+	//SBDS Version: 03.01
+	SBDSVersion string `dmi:"SBDS Version,normalize"`
+	//Maximum Error: 1% 或 Unknown
+	MaximumError string `dmi:"Maximum Error"`
+	//OEM-specific Information: 0x00000000
+	OEMInformation string `dmi:"OEM-specific Information"`
+}
+
+func (d *DmiDecode) QueryPortableBattery() ([]*PortableBattery, error) {
+	return d.QueryPortableBatteryContext(context.Background())
+}
+
+func (d *DmiDecode) QueryPortableBatteryContext(ctx context.Context) ([]*PortableBattery, error) {
+	records, err := d.query(ctx, "22")
+	if err != nil {
+		return nil, err
+	}
+	return batteriesFromRecords(records), nil
+}
+
+func ParsePortableBattery(r io.Reader) ([]*PortableBattery, error) {
+	records, err := ReadRecords(r)
+	if err != nil {
+		return nil, err
+	}
+	return batteriesFromRecords(records), nil
+}
+
+func (t *Table) QueryPortableBattery() ([]*PortableBattery, error) {
+	return batteriesFromRecords(t.Records()), nil
+}
+
+// 2.2之前的结构没有Design Capacity Multiplier, 相当于1
+func batteryCapacityMultiplier(s *Structure) int {
+	if int(s.Length) < 0x16 || len(s.Data) < 0x16 {
+		return 1
+	}
+	return int(s.Data[0x15])
+}
+
+func batteriesFromRecords(records []Record) []*PortableBattery {
+	var result []*PortableBattery
+	for index := range records {
+		if records[index].Name == "Portable Battery" {
+			battery := new(PortableBattery)
+			DecodeRecord(&records[index], battery)
+			if s := records[index].structure; s != nil {
+				battery.DesignCapacityMultiplier = batteryCapacityMultiplier(s)
+			}
+			result = append(result, battery)
+		}
+	}
+	return result
+}
+
+// 电池的生产日期, Manufacture Date为厂商填写的字符串, SBDS Manufacture Date为 yyyy-mm-dd
+type BatteryDate struct {
+	time.Time
+}
+
+// 无法解析时为零值
+func (d *BatteryDate) UnmarshalText(text []byte) error {
+	d.Time = time.Time{}
+	value := strings.TrimSpace(string(text))
+	if date, err := time.Parse("2006-01-02", value); err == nil {
+		d.Time = date
+		return nil
+	}
+	// 厂商填写的字符串与BIOS日期的写法类似, 例如 02/13/2014
+	d.Time, _ = ParseBIOSDate(value)
+	return nil
+}
+
+func (d BatteryDate) String() string {
+	if d.IsZero() {
+		return ""
+	}
+	return d.Format("2006-01-02")
+}
+
+// SBDS的日期, 第15到9位为年份减1980, 第8到5位为月, 第4到0位为日, 月或日无效时返回零值
+func SBDSDate(code uint16) time.Time {
+	year := 1980 + int(code>>9)
+	month := int(code>>5) & 0x0F
+	day := int(code & 0x1F)
+	result := time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+	if int(result.Month()) != month || result.Day() != day {
+		return time.Time{}
+	}
+	return result
+}
+
+// 最大误差的百分比, Unknown时返回false
+func (b *PortableBattery) MaximumErrorPercent() (int, bool) {
+	percent, err := strconv.Atoi(strings.TrimSuffix(b.MaximumError, "%"))
+	if err != nil {
+		return 0, false
+	}
+	return percent, true
+}
+
+// 距离电池生产的时间, 生产日期未知时返回false
+func (b *PortableBattery) Age() (time.Duration, bool) {
+	if b.Manufactured.IsZero() {
+		return 0, false
+	}
+	return now().Sub(b.Manufactured.Time), true
+}
+
+// 电池是否生产于years年之前, 生产日期未知时返回false
+func (b *PortableBattery) OlderThan(years int) bool {
+	if b.Manufactured.IsZero() {
+		return false
+	}
+	return b.Manufactured.AddDate(years, 0, 0).Before(now())
+}
@@ -0,0 +1,110 @@
+package dmidecode
+
+import (
+	"encoding/binary"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParsePortableBattery(t *testing.T) {
+	batteries, err := ParsePortableBattery(strings.NewReader(readTestdata(t, "battery.txt")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(batteries) != 2 {
+		t.Fatalf("batteries: got %d", len(batteries))
+	}
+	sbds := batteries[0]
+	if sbds.Handle != 0x002C || sbds.ManufactureDate != "2014-02-13" || sbds.Manufactured.String() != "2014-02-13" ||
+		sbds.SerialNumber != "0431" || sbds.Chemistry != "LION" || sbds.DesignCapacityMWh != 56160 ||
+		sbds.DesignVoltageVolts != 10.8 || sbds.SBDSVersion != "03.01" || sbds.Extra != nil ||
+		sbds.DesignCapacityMultiplier != 0 {
+		t.Errorf("sbds: %+v", sbds)
+	}
+	if _, ok := sbds.MaximumErrorPercent(); ok {
+		t.Errorf("maximum error: %q", sbds.MaximumError)
+	}
+
+	battery := batteries[1]
+	if battery.Manufactured.String() != "2016-09-22" || battery.Chemistry != "Lithium Ion" || battery.SBDSVersion != "" ||
+		battery.Raw["SBDS Version"] != "Not Specified" {
+		t.Errorf("battery: %+v", battery)
+	}
+	if percent, ok := battery.MaximumErrorPercent(); !ok || percent != 2 {
+		t.Errorf("maximum error: %d %v", percent, ok)
+	}
+}
+
+func TestPortableBattery_Age(t *testing.T) {
+	defer func(saved func() time.Time) { now = saved }(now)
+	now = func() time.Time { return time.Date(2019, 2, 14, 0, 0, 0, 0, time.UTC) }
+
+	batteries, err := ParsePortableBattery(strings.NewReader(readTestdata(t, "battery.txt")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if age, ok := batteries[0].Age(); !ok || age != now().Sub(time.Date(2014, 2, 13, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("age: %v %v", age, ok)
+	}
+	if !batteries[0].OlderThan(5) || batteries[1].OlderThan(3) {
+		t.Errorf("older than: %v %v", batteries[0].OlderThan(5), batteries[1].OlderThan(3))
+	}
+	if _, ok := new(PortableBattery).Age(); ok {
+		t.Error("unknown date: expected false")
+	}
+}
+
+func TestSBDSDate(t *testing.T) {
+	// 2014-02-13: (34 << 9) | (2 << 5) | 13
+	if got := SBDSDate(34<<9 | 2<<5 | 13); !got.Equal(time.Date(2014, 2, 13, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("got %v", got)
+	}
+	if got := SBDSDate(34<<9 | 0<<5 | 13); !got.IsZero() {
+		t.Errorf("month 0: got %v", got)
+	}
+}
+
+func TestDecodePortableBattery(t *testing.T) {
+	data := make([]byte, 26)
+	data[0], data[1] = 22, 26
+	data[0x04], data[0x05], data[0x08], data[0x0E], data[0x14] = 1, 2, 3, 4, 5
+	data[0x09] = 0x02
+	binary.LittleEndian.PutUint16(data[0x0A:], 2340)
+	binary.LittleEndian.PutUint16(data[0x0C:], 10800)
+	data[0x0F] = 0xFF
+	binary.LittleEndian.PutUint16(data[0x10:], 0x0431)
+	binary.LittleEndian.PutUint16(data[0x12:], 34<<9|2<<5|13)
+	data[0x15] = 24
+	s := &Structure{Type: 22, Length: 26, Handle: 0x002C, Data: data, Strings: []string{"Front", "LGC", "45N1011", "03.01", "LION"}}
+
+	batteries := batteriesFromRecords(decodeStructure(s, 0x0207))
+	if len(batteries) != 1 {
+		t.Fatalf("batteries: got %d", len(batteries))
+	}
+	battery := batteries[0]
+	if battery.Location != "Front" || battery.DesignCapacity != "56160 mWh" || battery.DesignVoltage != "10800 mV" ||
+		battery.SerialNumber != "0431" || battery.ManufactureDate != "2014-02-13" || battery.Chemistry != "LION" ||
+		battery.MaximumError != "Unknown" || battery.OEMInformation != "0x00000000" {
+		t.Errorf("decoded: %+v", battery)
+	}
+
+	table := &Table{EntryPoint: &EntryPoint{Major: 2, Minor: 7}, Structures: []*Structure{s}}
+	batteries, _ = table.QueryPortableBattery()
+	if battery := batteries[0]; battery.DesignCapacityMultiplier != 24 || battery.DesignCapacityMWh != 56160 ||
+		battery.Manufactured.String() != "2014-02-13" {
+		t.Errorf("table: %+v", battery)
+	}
+	// QueryAll与QueryPortableBattery一致
+	inventory, _ := table.QueryAll()
+	if len(inventory.Batteries) != 1 || inventory.Batteries[0].DesignCapacityMultiplier != 24 {
+		t.Errorf("inventory: %+v", inventory.Batteries)
+	}
+
+	// 月份无效时与dmidecode一致照样输出
+	binary.LittleEndian.PutUint16(data[0x12:], 34<<9|0<<5|13)
+	battery = batteriesFromRecords(decodeStructure(s, 0x0207))[0]
+	if battery.ManufactureDate != "2014-00-13" || !battery.Manufactured.IsZero() {
+		t.Errorf("invalid date: %q %v", battery.ManufactureDate, battery.Manufactured)
+	}
+}
@@ -0,0 +1,269 @@
+package dmidecode
+
+import (
+	"context"
+	"encoding"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// 根据 `dmi:"Key"` tag 解码记录, 用户可以只定义需要的字段:
+//
+//	type MyBoard struct {
+//		Serial   string   `dmi:"Serial Number"`
+//		Features []string `dmi:"Features"`
+//	}
+//
+// 字段类型与值的对应关系: string为原始值; []string为多行的值, 例如Characteristics;
+// 整数和浮点数取值的第一个单词, 例如 "64 bits" 为64, "0x0005" 为5, 无法解析时保持零值;
+// 实现了encoding.TextUnmarshaler的类型调用UnmarshalText; Optional中的占位符无效.
+// 不同版本的dmidecode对同一个字段的写法不同时, 可以用|分隔多个key, 例如 `dmi:"Configured Memory Speed|Configured Clock Speed"`.
+// tag中的 ",normalize" 表示这是自由文本字段, 占位符替换为空字符串, DecodeOptions.KeepPlaceholders为true时保留.
+// 名为Handle, DMIType, Extra, Raw且没有tag的字段分别设置为记录的句柄, DMI type, 没有对应tag的字段和被替换的占位符或不在取值表中的枚举的原始值.
+
+// 没有找到要解码的记录
+var ErrNoRecord = errors.New("dmidecode: no matching record")
+
+// dmidecode -t 的关键字对应的DMI type, memory对应Memory Device
+var keywordTypes = map[string]int{
+	"bios":      0,
+	"system":    1,
+	"baseboard": 2,
+	"chassis":   3,
+	"processor": 4,
+	"memory":    17,
+	"cache":     7,
+	"connector": 8,
+	"slot":      9,
+}
+
+// 关键字或数字形式的DMI type
+func keywordType(keyword string) (int, error) {
+	if dmiType, ok := keywordTypes[keyword]; ok {
+		return dmiType, nil
+	}
+	dmiType, err := strconv.Atoi(keyword)
+	if err != nil || dmiType < 0 || dmiType > 255 {
+		return 0, fmt.Errorf("dmidecode: unknown keyword %q", keyword)
+	}
+	return dmiType, nil
+}
+
+// 执行 dmidecode -t keyword 并解码到v, v为结构体指针时解码第一个记录, 为切片指针时解码全部记录
+func (d *DmiDecode) Decode(keyword string, v interface{}) error {
+	return d.DecodeContext(context.Background(), keyword, v)
+}
+
+func (d *DmiDecode) DecodeContext(ctx context.Context, keyword string, v interface{}) error {
+	dmiType, err := keywordType(keyword)
+	if err != nil {
+		return err
+	}
+	records, err := d.query(ctx, keyword)
+	if err != nil {
+		return err
+	}
+	return DecodeRecords(records, dmiType, v)
+}
+
+func (t *Table) Decode(keyword string, v interface{}) error {
+	dmiType, err := keywordType(keyword)
+	if err != nil {
+		return err
+	}
+	return DecodeRecords(t.Records(), dmiType, v)
+}
+
+// 解码选项, 零值为默认行为
+type DecodeOptions struct {
+	KeepPlaceholders bool // 为true时 ",normalize" 字段保留占位符的原始值
+}
+
+// 把DMI type为dmiType的记录解码到v, v为结构体指针或结构体(指针)切片的指针
+func DecodeRecords(records []Record, dmiType int, v interface{}) error {
+	return DecodeOptions{}.DecodeRecords(records, dmiType, v)
+}
+
+// 把一个记录解码到v, v为结构体指针
+func DecodeRecord(record *Record, v interface{}) error {
+	return DecodeOptions{}.DecodeRecord(record, v)
+}
+
+func (o DecodeOptions) DecodeRecords(records []Record, dmiType int, v interface{}) error {
+	target := reflect.ValueOf(v)
+	if target.Kind() != reflect.Ptr || target.IsNil() {
+		return fmt.Errorf("dmidecode: Decode needs a pointer, got %T", v)
+	}
+	target = target.Elem()
+	switch {
+	case target.Kind() == reflect.Struct:
+		for index := range records {
+			if records[index].Type == dmiType {
+				return o.decodeRecord(&records[index], target)
+			}
+		}
+		return ErrNoRecord
+	case target.Kind() == reflect.Slice && structType(target.Type().Elem()) != nil:
+		elemType := target.Type().Elem()
+		var firstErr error
+		for index := range records {
+			if records[index].Type != dmiType {
+				continue
+			}
+			elem := reflect.New(structType(elemType)).Elem()
+			if err := o.decodeRecord(&records[index], elem); err != nil && firstErr == nil {
+				firstErr = err
+			}
+			if elemType.Kind() == reflect.Ptr {
+				elem = elem.Addr()
+			}
+			target.Set(reflect.Append(target, elem))
+		}
+		return firstErr
+	}
+	return fmt.Errorf("dmidecode: cannot decode into %T", v)
+}
+
+func (o DecodeOptions) DecodeRecord(record *Record, v interface{}) error {
+	target := reflect.ValueOf(v)
+	if target.Kind() != reflect.Ptr || target.IsNil() || target.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("dmidecode: Decode needs a pointer to struct, got %T", v)
+	}
+	return o.decodeRecord(record, target.Elem())
+}
+
+// 结构体或结构体指针的结构体类型, 其他返回nil
+func structType(t reflect.Type) reflect.Type {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+	return t
+}
+
+var (
+	handleType    = reflect.TypeOf(Handle(0))
+	extraType     = reflect.TypeOf(map[string]string(nil))
+	unmarshalType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+	optionalType  = reflect.TypeOf(Optional{})
+)
+
+// 解码到结构体, 所有字段都会尝试, 返回第一个错误
+func (o DecodeOptions) decodeRecord(record *Record, target reflect.Value) error {
+	keep := o.KeepPlaceholders || record.keepPlaceholders
+	var firstErr error
+	var extra, raw reflect.Value
+	var raws map[string]string
+	used := make(map[string]bool)
+	structType := target.Type()
+	for index := 0; index < structType.NumField(); index++ {
+		field := structType.Field(index)
+		if field.PkgPath != "" {
+			continue
+		}
+		value := target.Field(index)
+		key, ok := field.Tag.Lookup("dmi")
+		if !ok {
+			switch {
+			case field.Name == "Handle" && field.Type == handleType:
+				value.SetUint(uint64(record.Handle))
+			case field.Name == "DMIType" && field.Type.Kind() == reflect.Int:
+				value.SetInt(int64(record.Type))
+			case field.Name == "Extra" && field.Type == extraType:
+				extra = value
+			case field.Name == "Raw" && field.Type == extraType:
+				raw = value
+			}
+			continue
+		}
+		if key == "-" {
+			continue
+		}
+		var normalize bool
+		if index := strings.Index(key, ","); index >= 0 {
+			normalize = key[index+1:] == "normalize"
+			key = key[:index]
+		}
+		// 多个key用|分隔, 用于不同版本dmidecode的不同写法, 取第一个存在的
+		var source *Field
+		for _, name := range strings.Split(key, "|") {
+			used[name] = true
+			if source == nil {
+				source = record.Field(name)
+			}
+		}
+		if source == nil {
+			continue
+		}
+		if value.Type() == optionalType {
+			value.Set(reflect.ValueOf(newOptional(source.Value, keep)))
+			continue
+		}
+		if normalize && !keep && value.Kind() == reflect.String && IsPlaceholder(source.Value) {
+			value.SetString("")
+			if source.Value != "" {
+				raws = addExtra(raws, Field{Key: source.Key, Value: source.Value})
+			}
+			continue
+		}
+		err := decodeField(source, value)
+		// 不在取值表中的枚举值为OutOfSpec, 与占位符一样在Raw中保留原始值, 不算错误
+		if _, ok := err.(*OutOfSpecError); ok {
+			raws = addExtra(raws, Field{Key: source.Key, Value: source.Value})
+			continue
+		}
+		if err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("dmidecode: %s %q: %v", record.Name, key, err)
+		}
+	}
+	if extra.IsValid() {
+		var result map[string]string
+		for _, field := range record.Fields {
+			if !used[field.Key] {
+				result = addExtra(result, field)
+			}
+		}
+		extra.Set(reflect.ValueOf(result))
+	}
+	if raw.IsValid() {
+		raw.Set(reflect.ValueOf(raws))
+	}
+	return firstErr
+}
+
+func decodeField(source *Field, value reflect.Value) error {
+	if value.CanAddr() && value.Addr().Type().Implements(unmarshalType) {
+		return value.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(source.Value))
+	}
+	// 数字只取第一个单词, 例如 "64 bits"
+	number := source.Value
+	if index := strings.IndexAny(number, " \t"); index >= 0 {
+		number = number[:index]
+	}
+	switch value.Kind() {
+	case reflect.String:
+		value.SetString(source.Value)
+	case reflect.Slice:
+		if value.Type().Elem().Kind() == reflect.String {
+			value.Set(reflect.ValueOf(append([]string(nil), source.List...)).Convert(value.Type()))
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if result, err := strconv.ParseInt(number, 0, value.Type().Bits()); err == nil {
+			value.SetInt(result)
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if result, err := strconv.ParseUint(number, 0, value.Type().Bits()); err == nil {
+			value.SetUint(result)
+		}
+	case reflect.Float32, reflect.Float64:
+		if result, err := strconv.ParseFloat(number, value.Type().Bits()); err == nil {
+			value.SetFloat(result)
+		}
+	}
+	return nil
+}
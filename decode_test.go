@@ -0,0 +1,89 @@
+package dmidecode
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type testBoard struct {
+	Handle   Handle
+	Serial   string   `dmi:"Serial Number"`
+	Features []string `dmi:"Features"`
+	Chassis  Handle   `dmi:"Chassis Handle"`
+	Ignored  string   `dmi:"-"`
+}
+
+type testDevice struct {
+	Locator string            `dmi:"Locator"`
+	Width   int               `dmi:"Total Width"`
+	Rank    uint8             `dmi:"Rank"`
+	Voltage float64           `dmi:"Configured Voltage"`
+	Bank    upperString       `dmi:"Bank Locator"`
+	Extra   map[string]string // 没有tag的字段
+}
+
+type upperString string
+
+func (u *upperString) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		return errors.New("empty")
+	}
+	*u = upperString(strings.ToUpper(string(text)))
+	return nil
+}
+
+func TestDmiDecode_Decode(t *testing.T) {
+	d := replayInstance(t)
+	var board testBoard
+	if err := d.Decode("baseboard", &board); err != nil {
+		t.Fatal(err)
+	}
+	want := testBoard{Handle: 0x0002, Serial: "ZZ0R958AGF4", Chassis: 0x0000,
+		Features: []string{"Board is a hosting board", "Board is replaceable"}}
+	if !reflect.DeepEqual(board, want) {
+		t.Errorf("board: got %+v, want %+v", board, want)
+	}
+}
+
+func TestDecodeRecords(t *testing.T) {
+	records := ParseRecords(readTestdata(t, "server-memory.txt"))
+	var devices []*testDevice
+	if err := DecodeRecords(records, 17, &devices); err != nil {
+		t.Fatal(err)
+	}
+	if len(devices) != 6 {
+		t.Fatalf("devices: got %d, want 6", len(devices))
+	}
+	first := devices[0]
+	if first.Locator != "CPU1_DIMM_A1" || first.Width != 72 || first.Rank != 2 || first.Voltage != 1.2 || first.Bank != "NODE 1" {
+		t.Errorf("device: %+v", first)
+	}
+	if first.Extra["Part Number"] != "M393A4K40BB2-CTD" || first.Extra["Locator"] != "" {
+		t.Errorf("extra: %q", first.Extra)
+	}
+	// Unknown等无法解析的数字保持零值
+	if empty := devices[1]; empty.Width != 0 || empty.Rank != 0 {
+		t.Errorf("empty device: %+v", empty)
+	}
+}
+
+func TestDecodeRecords_Errors(t *testing.T) {
+	records := ParseRecords(readTestdata(t, "server-memory.txt"))
+	var board testBoard
+	if err := DecodeRecords(records, 2, &board); err != ErrNoRecord {
+		t.Errorf("got %v, want ErrNoRecord", err)
+	}
+	if err := DecodeRecords(records, 2, board); err == nil {
+		t.Error("expected error for non-pointer")
+	}
+	var device testDevice
+	records[1].Field("Bank Locator").Value = ""
+	if err := DecodeRecords(records, 17, &device); err == nil || !strings.Contains(err.Error(), "Bank Locator") {
+		t.Errorf("got %v, want UnmarshalText error", err)
+	}
+	if _, err := keywordType("dimm"); err == nil {
+		t.Error("expected error for unknown keyword")
+	}
+}
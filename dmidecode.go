@@ -1,11 +1,10 @@
 package dmidecode
 
 import (
-	"fmt"
+	"context"
 	"log"
 	"os"
-	"strings"
-	"regexp"
+
 	osutils "github.com/doggytty/goutils/systems"
 )
 
@@ -19,6 +18,11 @@ type DmiDecode struct {
 	IsRoot   bool   // 是否root用户启动
 	IsActive bool   // 是否可用
 	Password string // 使用sudo执行命令需要传入的password
+	Runner   Runner // 执行命令的方式, 为空时直接执行; 不为空且Path不为空时不再降级到 /sys/class/dmi/id
+	// 不为空时, 每次查询后把没有对应字段的key(见UnknownKeys)传给Unknown
+	Unknown func(keys []string)
+	// 为true时序列号, 厂商等字段保留 "To Be Filled By O.E.M." 这类占位符, 默认替换为空字符串
+	KeepPlaceholders bool
 }
 
 func Instance() *DmiDecode {
@@ -43,890 +47,730 @@ func Instance() *DmiDecode {
 	return d
 }
 
+// 使用sudo执行, 密码从标准输入传给sudo
 func (d *DmiDecode) SetPassword(password string) {
 	d.Password = password
+	d.SetCredential(func(ctx context.Context) (string, error) {
+		return password, nil
+	})
+}
+
+// 使用sudo执行, 每次执行时通过credential获取密码
+func (d *DmiDecode) SetCredential(credential CredentialFunc) {
+	d.Runner = &SudoRunner{Credential: credential}
 	// 设置当前模块是否可用
 	if d.Path != "" {
 		d.IsActive = true
 	}
-	// 重新设置d.path
-	d.Path = fmt.Sprintf("echo %s | sudo -S %s ", password, d.Path)
 	if DEBUG {
-		log.Printf("renew path: %s\n", d.Path)
+		log.Printf("use sudo to run %s\n", d.Path)
 	}
 }
 
+// 检查 sudo -n 能否不输入密码执行dmidecode, 可以时改为通过sudo执行
+func (d *DmiDecode) SudoPreflight(ctx context.Context) error {
+	if d.Path == "" {
+		return errNoCommand
+	}
+	runner := &SudoRunner{}
+	if err := runner.Preflight(ctx, d.Path); err != nil {
+		if DEBUG {
+			log.Println(err)
+		}
+		return err
+	}
+	d.Runner = runner
+	d.IsActive = true
+	return nil
+}
+
+// 执行 dmidecode -t keyword 并解析输出, 没有keyword时输出全部信息, ctx取消或超时时结束子进程
+func (d *DmiDecode) query(ctx context.Context, keywords ...string) ([]Record, error) {
+	args := []string{d.Path}
+	for _, keyword := range keywords {
+		args = append(args, "-t", keyword)
+	}
+	if DEBUG {
+		log.Printf("now query %v info: %v\n", keywords, args)
+	}
+	runner := d.Runner
+	if runner == nil {
+		runner = ExecRunner{}
+	}
+	output, err := runner.Run(ctx, args)
+	if err != nil {
+		err = contextError(ctx, err)
+		if DEBUG {
+			log.Println(err)
+		}
+		return nil, err
+	}
+	records := ParseRecords(string(output))
+	for index := range records {
+		records[index].keepPlaceholders = d.KeepPlaceholders
+	}
+	if d.Unknown != nil {
+		if keys := UnknownKeys(records); len(keys) > 0 {
+			d.Unknown(keys)
+		}
+	}
+	return records, nil
+}
+
+// 是否通过dmidecode查询: root用户或设置了密码(IsActive), 或者设置了Runner(例如远程执行或沙箱),
+// 否则 bios/system/baseboard/chassis 和QueryAll降级到 /sys/class/dmi/id
+func (d *DmiDecode) active() bool {
+	return d.IsActive || (d.Runner != nil && d.Path != "")
+}
+
+// 降级时读取的 sys/class/dmi/id 所在的根目录, 为空时使用"/", 便于测试
+var fallbackRoot = ""
+
+// 不可用(不是root, 也没有设置密码)时, bios/system/baseboard/chassis 从 /sys/class/dmi/id 读取
+func (d *DmiDecode) fallback() *DmiID {
+	if DEBUG {
+		log.Println("dmidecode is not active, fallback to /sys/class/dmi/id")
+	}
+	return &DmiID{Root: fallbackRoot, KeepPlaceholders: d.KeepPlaceholders}
+}
+
 // dmidecode -t bios
 type BiosInfo struct {
+	Handle  Handle            // DMI结构的句柄
+	DMIType int               // DMI type, dmidecode -q 的输出中为-1
+	Extra   map[string]string // 没有对应字段的key, 列表值用换行连接
+	Raw     map[string]string // 被替换的占位符和不在取值表中的枚举的原始值
 	//Vendor: LENOVO
-	Vendor          string
+	Vendor string `dmi:"Vendor,normalize"`
 	//Version: J4ET76WW(1.76)
-	Version         string
+	Version string `dmi:"Version,normalize"`
 	//Address: 0xE0000
-	Address         string
+	Address string `dmi:"Address"`
 	//BIOS Revision: 1.76
-	BIOSRevision    string
+	BIOSRevision       string   `dmi:"BIOS Revision"`
+	BIOSRevisionNumber Revision `dmi:"BIOS Revision"`
+	//Firmware Revision: 1.21
+	FirmwareRevision       string   `dmi:"Firmware Revision"`
+	FirmwareRevisionNumber Revision `dmi:"Firmware Revision"`
 	//Release Date: 03/03/2015
-	ReleaseDate     string
+	ReleaseDate string   `dmi:"Release Date"`
+	Released    BIOSDate `dmi:"Release Date"`
 	//Runtime Size: 128 kB
-	RuntimeSize     string
+	RuntimeSize      string `dmi:"Runtime Size"`
+	RuntimeSizeBytes Size   `dmi:"Runtime Size"`
 	//ROM Size: 8192 kB
-	RomSize         string
+	RomSize      string `dmi:"ROM Size"`
+	RomSizeBytes Size   `dmi:"ROM Size"`
 	//Characteristics:
-	Characteristics []string
+	Characteristics []string `dmi:"Characteristics"`
+	// 因权限不足无法读取的字段, 只在使用 /sys/class/dmi/id 时出现
+	Unavailable []string
 }
 
 type BiosLanguage struct {
+	Handle  Handle            // DMI结构的句柄
+	DMIType int               // DMI type, dmidecode -q 的输出中为-1
+	Extra   map[string]string // 没有对应字段的key, 列表值用换行连接
+	Raw     map[string]string // 被替换的占位符和不在取值表中的枚举的原始值
 	//Language Description Format: Abbreviated
-	LanguageDescriptionFormat string
+	LanguageDescriptionFormat string `dmi:"Language Description Format"`
 	//Installable Languages: 7
-	InstallableLanguagesNumber int
-	InstallableLanguages []string
+	InstallableLanguagesNumber int      `dmi:"Installable Languages"`
+	InstallableLanguages       []string `dmi:"Installable Languages"`
 	//Currently Installed Language: en-US
-	CurrentlyInstalledLanguage string
+	CurrentlyInstalledLanguage string `dmi:"Currently Installed Language"`
 }
 
 func (d *DmiDecode) QueryBIOS() (*BiosInfo, *BiosLanguage, error) {
-	cmd := fmt.Sprintf("%s -t bios", d.Path)
-	if DEBUG {
-		log.Println("now query bios info: " + cmd)
+	return d.QueryBIOSContext(context.Background())
+}
+
+func (d *DmiDecode) QueryBIOSContext(ctx context.Context) (*BiosInfo, *BiosLanguage, error) {
+	if !d.active() {
+		return d.fallback().QueryBIOS()
 	}
-	bios, err := osutils.ExecuteCommand(cmd)
+	records, err := d.query(ctx, "bios")
 	if err != nil {
-		if DEBUG {
-			log.Println(err)
-		}
 		return nil, nil, err
 	}
+	result, language := biosFromRecords(records)
+	return result, language, nil
+}
+
+func biosFromRecords(records []Record) (*BiosInfo, *BiosLanguage) {
 	var result *BiosInfo = new(BiosInfo)
 	var language *BiosLanguage = new(BiosLanguage)
-	biosArray := strings.Split(bios, "\n\n")
-	for _, biosInfo := range biosArray {
-		if strings.Contains(biosInfo, "\nBIOS Information\n") {
-			re, _ := regexp.Compile("\n\t\t")
-			biosInfo = re.ReplaceAllString(biosInfo, "|")
-			re, _ = regexp.Compile("\n\t([^\n\t].*)")
-			biosInfoArray := re.FindAllString(biosInfo, -1)
-			for _, subBiosInfo := range biosInfoArray {
-				subBiosInfoArray := strings.Split(subBiosInfo, ":")
-				if len(subBiosInfoArray) == 2 {
-					key := strings.TrimSpace(subBiosInfoArray[0])
-					value := strings.TrimSpace(subBiosInfoArray[1])
-					switch key {
-					case "Vendor":
-						result.Vendor = value
-					case "Version":
-						result.Version = value
-					case "Release Date":
-						result.ReleaseDate = value
-					case "Address":
-						result.Address = value
-					case "Runtime Size":
-						result.RuntimeSize = value
-					case "ROM Size":
-						result.RomSize = value
-					case "Characteristics":
-						characterArray := strings.Split(value, "|")
-						for index, subValue := range characterArray {
-							characterArray[index] = strings.TrimSpace(subValue)
-						}
-						result.Characteristics = characterArray
-					case "BIOS Revision":
-						result.BIOSRevision = value
-					}
-				}
-			}
-		} else if strings.Contains(biosInfo, "\nBIOS Language Information\n") {
-			re, _ := regexp.Compile("\n\t\t")
-			biosInfo = re.ReplaceAllString(biosInfo, "|")
-			re, _ = regexp.Compile("\n\t([^\n\t].*)")
-			languageArray := re.FindAllString(biosInfo, -1)
-			for _, subLanguage := range languageArray {
-				subLanguageArray := strings.Split(subLanguage, ":")
-				if len(subLanguageArray) == 2 {
-					key := strings.TrimSpace(subLanguageArray[0])
-					value := strings.TrimSpace(subLanguageArray[1])
-					switch key {
-					case "Language Description Format":
-						language.LanguageDescriptionFormat = value
-					case "Currently Installed Language":
-						language.CurrentlyInstalledLanguage = value
-					case "Installable Languages":
-						tmpArray := strings.Split(value, "|")
-						for index, subValue := range tmpArray {
-							tmpArray[index] = strings.TrimSpace(subValue)
-						}
-						language.InstallableLanguagesNumber = len(tmpArray) - 1
-						language.InstallableLanguages = tmpArray[1:]
-					}
-				}
-			}
+	for index := range records {
+		switch records[index].Name {
+		case "BIOS Information":
+			DecodeRecord(&records[index], result)
+		case "BIOS Language Information":
+			DecodeRecord(&records[index], language)
 		}
 	}
-	return result, language, nil
+	return result, language
 }
 
 // dmidecode -t system
 type SystemInfo struct {
-	Manufacturer string
-	ProductName  string
-	Version      string
-	SerialNumber string
-	UUID         string
-	WakeUpType   string
-	SKUNumber    string
-	Family       string
+	Handle               Handle            // DMI结构的句柄
+	DMIType              int               // DMI type, dmidecode -q 的输出中为-1
+	Extra                map[string]string // 没有对应字段的key, 列表值用换行连接
+	Raw                  map[string]string // 被替换的占位符和不在取值表中的枚举的原始值
+	Manufacturer         string            `dmi:"Manufacturer,normalize"`
+	ManufacturerOptional Optional          `dmi:"Manufacturer,normalize"`
+	ProductName          string            `dmi:"Product Name,normalize"`
+	Version              string            `dmi:"Version,normalize"`
+	SerialNumber         string            `dmi:"Serial Number,normalize"`
+	SerialNumberOptional Optional          `dmi:"Serial Number,normalize"`
+	UUID                 string            `dmi:"UUID"`
+	SystemUUID           UUID              `dmi:"UUID"`
+	WakeUpType           WakeUpType        `dmi:"Wake-up Type"`
+	SKUNumber            string            `dmi:"SKU Number,normalize"`
+	Family               string            `dmi:"Family,normalize"`
+	// SystemUUID所在的SMBIOS版本, 例如0x0207, SystemUUID.Bytes(SMBIOSVersion)得到表中的原始字节;
+	// 未知时为0, 例如使用 /sys/class/dmi/id 或 dmidecode -q 时
+	SMBIOSVersion int
+	// 因权限不足无法读取的字段, 只在使用 /sys/class/dmi/id 时出现
+	Unavailable []string
 }
 
 func (d *DmiDecode) QuerySystem() (*SystemInfo, error) {
-	cmd := fmt.Sprintf("%s -t System", d.Path)
-	if DEBUG {
-		log.Println("now query System info: " + cmd)
+	return d.QuerySystemContext(context.Background())
+}
+
+func (d *DmiDecode) QuerySystemContext(ctx context.Context) (*SystemInfo, error) {
+	if !d.active() {
+		return d.fallback().QuerySystem()
 	}
-	system, err := osutils.ExecuteCommand(cmd)
+	records, err := d.query(ctx, "system")
 	if err != nil {
-		if DEBUG {
-			log.Println(err)
-		}
 		return nil, err
 	}
+	return systemFromRecords(records), nil
+}
+
+func systemFromRecords(records []Record) *SystemInfo {
 	var result *SystemInfo = new(SystemInfo)
-	systemArray := strings.Split(system, "\n\n")
-	for _, systemInfo := range systemArray {
-		if strings.Contains(systemInfo, "\nSystem Information\n") {
-			re, _ := regexp.Compile("\n\t\t")
-			systemInfo = re.ReplaceAllString(systemInfo, "|")
-			re, _ = regexp.Compile("\n\t([^\n\t].*)")
-			systemInfoArray := re.FindAllString(systemInfo, -1)
-			for _, subSystemInfo := range systemInfoArray {
-				subSystemInfoArray := strings.Split(subSystemInfo, ":")
-				if len(subSystemInfoArray) == 2 {
-					key := strings.TrimSpace(subSystemInfoArray[0])
-					value := strings.TrimSpace(subSystemInfoArray[1])
-					switch key {
-					case "Manufacturer":
-						result.Manufacturer = value
-					case "Product Name":
-						result.ProductName = value
-					case "Version":
-						result.Version = value
-					case "Serial Number":
-						result.SerialNumber = value
-					case "UUID":
-						result.UUID = value
-					case "Wake-up Type":
-						result.WakeUpType = value
-					case "SKU Number":
-						result.SKUNumber = value
-					case "Family":
-						result.Family = value
-					}
-				}
-			}
+	for index := range records {
+		if records[index].Name == "System Information" {
+			DecodeRecord(&records[index], result)
+			result.SMBIOSVersion = records[index].version
 		}
 	}
-	return result, nil
+	return result
 }
 
 // dmidecode -t baseboard
 type BaseBoardInfo struct {
+	Handle  Handle            // DMI结构的句柄
+	DMIType int               // DMI type, dmidecode -q 的输出中为-1
+	Extra   map[string]string // 没有对应字段的key, 列表值用换行连接
+	Raw     map[string]string // 被替换的占位符和不在取值表中的枚举的原始值
 	//Manufacturer: LENOVO
-	Manufacturer string
+	Manufacturer         string   `dmi:"Manufacturer,normalize"`
+	ManufacturerOptional Optional `dmi:"Manufacturer,normalize"`
 	//Product Name: 20ASEB3
-	ProductName string
+	ProductName string `dmi:"Product Name,normalize"`
 	//Version: No DPK
-	Version string
+	Version string `dmi:"Version,normalize"`
 	//Serial Number: ZZ0R958AGF4
-	SerialNumber string
+	SerialNumber         string   `dmi:"Serial Number,normalize"`
+	SerialNumberOptional Optional `dmi:"Serial Number,normalize"`
 	//Asset Tag: Not Available
-	AssertTag string
+	AssertTag        string   `dmi:"Asset Tag,normalize"`
+	AssetTagOptional Optional `dmi:"Asset Tag,normalize"`
 	//Features:
 	//Board is a hosting board
 	//Board is replaceable
-	Features []string
+	Features []string `dmi:"Features"`
 	//Location In Chassis: Not Available
-	LocationInChassis string
+	LocationInChassis string `dmi:"Location In Chassis,normalize"`
 	//Chassis Handle: 0x0000
-	ChassisHandle string
+	ChassisHandle string `dmi:"Chassis Handle"`
 	//Type: Motherboard
-	Type string
+	Type string `dmi:"Type"`
 	//Contained Object Handles: 0
-	ContainedObjectHandles string
+	ContainedObjectHandles string `dmi:"Contained Object Handles"`
+	// 因权限不足无法读取的字段, 只在使用 /sys/class/dmi/id 时出现
+	Unavailable []string
+
+	chassis *ChassisInfo
 }
 
 func (d *DmiDecode) QueryBaseBoard() (*BaseBoardInfo, error) {
-	cmd := fmt.Sprintf("%s -t baseboard", d.Path)
-	if DEBUG {
-		log.Println("now query baseboard info: " + cmd)
+	return d.QueryBaseBoardContext(context.Background())
+}
+
+func (d *DmiDecode) QueryBaseBoardContext(ctx context.Context) (*BaseBoardInfo, error) {
+	if !d.active() {
+		return d.fallback().QueryBaseBoard()
 	}
-	baseBoard, err := osutils.ExecuteCommand(cmd)
+	// Chassis()引用的type 3不在 -t baseboard 的输出中
+	records, err := d.query(ctx, "baseboard", "chassis")
 	if err != nil {
-		if DEBUG {
-			log.Println(err)
-		}
 		return nil, err
 	}
+	return baseBoardFromRecords(records), nil
+}
+
+func baseBoardFromRecords(records []Record) *BaseBoardInfo {
 	var result *BaseBoardInfo = new(BaseBoardInfo)
-	baseBoardArray := strings.Split(baseBoard, "\n\n")
-	for _, baseBoardInfo := range baseBoardArray {
-		if strings.Contains(baseBoardInfo, "\nBase Board Information\n") {
-			re, _ := regexp.Compile("\n\t\t")
-			baseBoardInfo = re.ReplaceAllString(baseBoardInfo, "|")
-			re, _ = regexp.Compile("\n\t([^\n\t].*)")
-			baseBoardInfoArray := re.FindAllString(baseBoardInfo, -1)
-			for _, subBaseBoardInfo := range baseBoardInfoArray {
-				subBaseBoardInfoArray := strings.Split(subBaseBoardInfo, ":")
-				if len(subBaseBoardInfoArray) == 2 {
-					key := strings.TrimSpace(subBaseBoardInfoArray[0])
-					value := strings.TrimSpace(subBaseBoardInfoArray[1])
-					switch key {
-					case "Manufacturer":
-						result.Manufacturer = value
-					case "Product Name":
-						result.ProductName = value
-					case "Version":
-						result.Version = value
-					case "Serial Number":
-						result.SerialNumber = value
-					case "Asset Tag":
-						result.AssertTag = value
-					case "Location In Chassis":
-						result.LocationInChassis = value
-					case "Chassis Handle":
-						result.ChassisHandle = value
-					case "Type":
-						result.Type = value
-					case "Contained Object Handles":
-						result.ContainedObjectHandles = value
-					case "Features":
-						featuresArray := strings.Split(value, "|")
-						for index, subValue := range featuresArray {
-							featuresArray[index] = strings.TrimSpace(subValue)
-						}
-						result.Features = featuresArray
-					}
-				}
-			}
+	for index := range records {
+		if records[index].Name == "Base Board Information" {
+			DecodeRecord(&records[index], result)
 		}
 	}
-	return result, nil
+	result.chassis = chassisByHandle(records, result.ChassisHandle)
+	return result
 }
 
 // dmidecode -t chassis
 type ChassisInfo struct {
+	Handle  Handle            // DMI结构的句柄
+	DMIType int               // DMI type, dmidecode -q 的输出中为-1
+	Extra   map[string]string // 没有对应字段的key, 列表值用换行连接
+	Raw     map[string]string // 被替换的占位符和不在取值表中的枚举的原始值
 	//Manufacturer: LENOVO
-	Manufacturer string
+	Manufacturer         string   `dmi:"Manufacturer,normalize"`
+	ManufacturerOptional Optional `dmi:"Manufacturer,normalize"`
 	//Type: Notebook
-	Type string
+	Type ChassisType `dmi:"Type"`
 	//Lock: Not Present
-	Lock string
+	Lock string `dmi:"Lock"`
 	//Version: Not Available
-	Version string
+	Version string `dmi:"Version,normalize"`
 	//Serial Number: ZZ0R958AGF4
-	SerialNumber string
+	SerialNumber         string   `dmi:"Serial Number,normalize"`
+	SerialNumberOptional Optional `dmi:"Serial Number,normalize"`
 	//Asset Tag: Not Available
-	AssertTag string
+	AssertTag        string   `dmi:"Asset Tag,normalize"`
+	AssetTagOptional Optional `dmi:"Asset Tag,normalize"`
 	//Boot-up State: Unknown
-	BootUpState string
+	BootUpState string `dmi:"Boot-up State"`
 	//Power Supply State: Unknown
-	PowerSupplyState string
+	PowerSupplyState string `dmi:"Power Supply State"`
 	//Thermal State: Unknown
-	ThermalState string
+	ThermalState string `dmi:"Thermal State"`
 	//Security Status: Unknown
-	SecurityStatus string
+	SecurityStatus string `dmi:"Security Status"`
 	//OEM Information: 0x00000000
-	OEMInformation string
+	OEMInformation string `dmi:"OEM Information"`
 	//Height: Unspecified
-	Height string
+	Height string `dmi:"Height"`
 	//Number Of Power Cords: Unspecified
-	NumberOfPowerCords string
+	NumberOfPowerCords string `dmi:"Number Of Power Cords"`
 	//Contained Elements: 0
-	ContainedElements string
+	ContainedElements string `dmi:"Contained Elements"`
 	//SKU Number: Not Specified
-	SKUNumber string
+	SKUNumber string `dmi:"SKU Number,normalize"`
+	// 因权限不足无法读取的字段, 只在使用 /sys/class/dmi/id 时出现
+	Unavailable []string
 }
 
 func (d *DmiDecode) QueryChassis() (*ChassisInfo, error) {
-	cmd := fmt.Sprintf("%s -t chassis", d.Path)
-	if DEBUG {
-		log.Println("now query chassis info: " + cmd)
+	return d.QueryChassisContext(context.Background())
+}
+
+func (d *DmiDecode) QueryChassisContext(ctx context.Context) (*ChassisInfo, error) {
+	if !d.active() {
+		return d.fallback().QueryChassis()
 	}
-	chassis, err := osutils.ExecuteCommand(cmd)
+	records, err := d.query(ctx, "chassis")
 	if err != nil {
-		if DEBUG {
-			log.Println(err)
-		}
 		return nil, err
 	}
+	return chassisFromRecords(records), nil
+}
+
+func chassisFromRecords(records []Record) *ChassisInfo {
 	var result *ChassisInfo = new(ChassisInfo)
-	chassisArray := strings.Split(chassis, "\n\n")
-	for _, chassisInfo := range chassisArray {
-		if strings.Contains(chassisInfo, "\nChassis Information\n") {
-			re, _ := regexp.Compile("\n\t\t")
-			chassisInfo = re.ReplaceAllString(chassisInfo, "|")
-			re, _ = regexp.Compile("\n\t([^\n\t].*)")
-			chassisInfoArray := re.FindAllString(chassisInfo, -1)
-			for _, subChassisInfo := range chassisInfoArray {
-				subChassisInfoArray := strings.Split(subChassisInfo, ":")
-				if len(subChassisInfoArray) == 2 {
-					key := strings.TrimSpace(subChassisInfoArray[0])
-					value := strings.TrimSpace(subChassisInfoArray[1])
-					switch key {
-					case "Manufacturer":
-						result.Manufacturer = value
-					case "Type":
-						result.Type = value
-					case "Lock":
-						result.Lock = value
-					case "Version":
-						result.Version = value
-					case "Serial Number":
-						result.SerialNumber = value
-					case "Asset Tag":
-						result.AssertTag = value
-					case "Boot-up State":
-						result.BootUpState = value
-					case "Thermal State":
-						result.ThermalState = value
-					case "Power Supply State":
-						result.PowerSupplyState = value
-					case "Security Status":
-						result.SecurityStatus = value
-					case "OEM Information":
-						result.SecurityStatus = value
-					case "Height":
-						result.SecurityStatus = value
-					case "Number Of Power Cords":
-						result.SecurityStatus = value
-					case "Contained Elements":
-						result.SecurityStatus = value
-					case "SKU Number":
-						result.SecurityStatus = value
-					}
-				}
-			}
+	for index := range records {
+		if records[index].Name == "Chassis Information" {
+			DecodeRecord(&records[index], result)
 		}
 	}
-	return result, nil
+	return result
 }
 
 // dmidecode -t processor
 type ProcessorInfo struct {
+	Handle  Handle            // DMI结构的句柄
+	DMIType int               // DMI type, dmidecode -q 的输出中为-1
+	Extra   map[string]string // 没有对应字段的key, 列表值用换行连接
+	Raw     map[string]string // 被替换的占位符和不在取值表中的枚举的原始值
 	//Socket Designation: CPU Socket - U3E1
-	SocketDesignation string
+	SocketDesignation string `dmi:"Socket Designation,normalize"`
 	//Type: Central Processor
-	Type string
+	Type string `dmi:"Type"`
 	//Family: Core i7
-	Family string
+	Family string `dmi:"Family"`
 	//Manufacturer: Intel(R) Corporation
-	Manufacturer string
+	Manufacturer         string   `dmi:"Manufacturer,normalize"`
+	ManufacturerOptional Optional `dmi:"Manufacturer,normalize"`
 	//ID: C3 06 03 00 FF FB EB BF
-	ID string
+	ID string `dmi:"ID"`
 	//Signature: Type 0, Family 6, Model 60, Stepping 3
-	Signature string
+	Signature string `dmi:"Signature"`
 	//Flags
-	Flags []string
+	Flags []string `dmi:"Flags"`
 	//Version: Intel(R) Core(TM) i7-4712MQ CPU @ 2.30GHz
-	Version string
+	Version string `dmi:"Version,normalize"`
 	//Voltage: 0.7 V
-	Voltage string
+	Voltage string  `dmi:"Voltage"`
+	Volts   Voltage `dmi:"Voltage"`
 	//External Clock: 100 MHz
-	ExternalClock string
+	ExternalClock   string    `dmi:"External Clock"`
+	ExternalClockHz Frequency `dmi:"External Clock"`
 	//Max Speed: 2300 MHz
-	MaxSpeed string
+	MaxSpeed   string    `dmi:"Max Speed"`
+	MaxSpeedHz Frequency `dmi:"Max Speed"`
 	//Current Speed: 2300 MHz
-	CurrentSpeed string
+	CurrentSpeed   string    `dmi:"Current Speed"`
+	CurrentSpeedHz Frequency `dmi:"Current Speed"`
 	//Status: Populated, Enabled
-	Status string
+	Status string `dmi:"Status"`
 	//Upgrade: Socket rPGA988B
-	Upgrade string
+	Upgrade string `dmi:"Upgrade"`
 	//L1 Cache Handle: 0x0002
-	L1CacheHandle string
-	L2CacheHandle string
-	L3CacheHandle string
+	L1CacheHandle string `dmi:"L1 Cache Handle"`
+	L2CacheHandle string `dmi:"L2 Cache Handle"`
+	L3CacheHandle string `dmi:"L3 Cache Handle"`
 	//Serial Number: To Be Filled By O.E.M.
-	SerialNumber string
+	SerialNumber         string   `dmi:"Serial Number,normalize"`
+	SerialNumberOptional Optional `dmi:"Serial Number,normalize"`
 	//Asset Tag: To Be Filled By O.E.M.
-	AssetTag string
+	AssetTag         string   `dmi:"Asset Tag,normalize"`
+	AssetTagOptional Optional `dmi:"Asset Tag,normalize"`
 	//Part Number: To Be Filled By O.E.M.
-	PartNumber string
+	PartNumber string `dmi:"Part Number,normalize"`
 	//Core Count: 4
-	CoreCount string
+	CoreCount string `dmi:"Core Count"`
+	Cores     int    `dmi:"Core Count"`
 	//Core Enabled: 4
-	CoreEnabled string
+	CoreEnabled  string `dmi:"Core Enabled"`
+	EnabledCores int    `dmi:"Core Enabled"`
 	//Thread Count: 8
-	ThreadCount string
+	ThreadCount string `dmi:"Thread Count"`
+	Threads     int    `dmi:"Thread Count"`
 	//Characteristics:
-	Characteristics []string
+	Characteristics []string `dmi:"Characteristics"`
+
+	caches []*CacheInfo
 }
 
 func (d *DmiDecode) QueryProcessor() (*ProcessorInfo, error) {
-	cmd := fmt.Sprintf("%s -t processor", d.Path)
-	if DEBUG {
-		log.Println("now query chassis info: " + cmd)
-	}
-	processor, err := osutils.ExecuteCommand(cmd)
+	return d.QueryProcessorContext(context.Background())
+}
+
+// 同时查询cache, 用于ProcessorInfo.Caches()
+func (d *DmiDecode) QueryProcessorContext(ctx context.Context) (*ProcessorInfo, error) {
+	records, err := d.query(ctx, "processor", "cache")
 	if err != nil {
-		if DEBUG {
-			log.Println(err)
-		}
 		return nil, err
 	}
-	var result *ProcessorInfo = new(ProcessorInfo)
-	processorArray := strings.Split(processor, "\n\n")
-	for _, processorInfo := range processorArray {
-		if strings.Contains(processorInfo, "\nProcessor Information\n") {
-			re, _ := regexp.Compile("\n\t\t")
-			processorInfo = re.ReplaceAllString(processorInfo, "|")
-			re, _ = regexp.Compile("\n\t([^\n\t].*)")
-			processorInfoArray := re.FindAllString(processorInfo, -1)
-			for _, subProcessorInfo := range processorInfoArray {
-				subProcessorInfoArray := strings.Split(subProcessorInfo, ":")
-				if len(subProcessorInfoArray) == 2 {
-					key := strings.TrimSpace(subProcessorInfoArray[0])
-					value := strings.TrimSpace(subProcessorInfoArray[1])
-					switch key {
-					case "Socket Designation":
-						result.SocketDesignation = value
-					case "Type":
-						result.Type = value
-					case "Family":
-						result.Family = value
-					case "Manufacturer":
-						result.Manufacturer = value
-					case "ID":
-						result.ID = value
-					case "Signature":
-						result.Signature = value
-					case "Flags":
-						flagsArray := strings.Split(value, "|")
-						for index, subValue := range flagsArray {
-							flagsArray[index] = strings.TrimSpace(subValue)
-						}
-						result.Flags = flagsArray
-					case "Version":
-						result.Version = value
-					case "Voltage":
-						result.Voltage = value
-					case "External Clock":
-						result.ExternalClock = value
-					case "Max Speed":
-						result.MaxSpeed = value
-					case "Current Speed":
-						result.CurrentSpeed = value
-					case "Status":
-						result.Status = value
-					case "Upgrade":
-						result.Upgrade = value
-					case "L1 Cache Handle":
-						result.L1CacheHandle = value
-					case "L2 Cache Handle":
-						result.L2CacheHandle = value
-					case "Serial Number":
-						result.SerialNumber = value
-					case "Asset Tag":
-						result.AssetTag = value
-					case "Part Number":
-						result.PartNumber = value
-					case "Core Count":
-						result.CoreCount = value
-					case "Core Enabled":
-						result.CoreEnabled = value
-					case "Thread Count":
-						result.ThreadCount = value
-					case "Characteristics":
-						characterArray := strings.Split(value, "|")
-						for index, subValue := range characterArray {
-							characterArray[index] = strings.TrimSpace(subValue)
-						}
-						result.Flags = characterArray
-					}
-				}
-			}
+	return processorFromRecords(records), nil
+}
+
+// 多路服务器只返回最后一个处理器, 需要全部时使用processorsFromRecords
+func processorFromRecords(records []Record) *ProcessorInfo {
+	processors := processorsFromRecords(records)
+	if len(processors) == 0 {
+		return new(ProcessorInfo)
+	}
+	return processors[len(processors)-1]
+}
+
+func processorsFromRecords(records []Record) []*ProcessorInfo {
+	return linkProcessors(records, cacheFromRecords(records))
+}
+
+// Caches()返回cacheList中的元素
+func linkProcessors(records []Record, cacheList []*CacheInfo) []*ProcessorInfo {
+	var result []*ProcessorInfo
+	caches := make(map[Handle]*CacheInfo)
+	for _, cache := range cacheList {
+		if cache.DMIType >= 0 {
+			caches[cache.Handle] = cache
+		}
+	}
+	for index := range records {
+		if records[index].Name == "Processor Information" {
+			processor := processorFromRecord(&records[index])
+			processor.linkCaches(caches)
+			result = append(result, processor)
 		}
 	}
-	return result, nil
+	return result
+}
+
+func processorFromRecord(record *Record) *ProcessorInfo {
+	var result *ProcessorInfo = new(ProcessorInfo)
+	DecodeRecord(record, result)
+	return result
 }
 
 // dmidecode -t memory
 type MemoryInfo struct {
+	Handle  Handle            // DMI结构的句柄
+	DMIType int               // DMI type, dmidecode -q 的输出中为-1
+	Extra   map[string]string // 没有对应字段的key, 列表值用换行连接
+	Raw     map[string]string // 被替换的占位符和不在取值表中的枚举的原始值
 	//Location: System Board Or Motherboard
-	Location string
+	Location string `dmi:"Location"`
 	//Use: System Memory
-	Use string
+	Use string `dmi:"Use"`
 	//Error Correction Type: None
-	ErrorCorrectionType string
+	ErrorCorrectionType ErrorCorrectionType `dmi:"Error Correction Type"`
 	//Maximum Capacity: 16 GB
-	MaximumCapacity string
+	MaximumCapacity      string `dmi:"Maximum Capacity"`
+	MaximumCapacityBytes Size   `dmi:"Maximum Capacity"`
 	//Error Information Handle: Not Provided
-	ErrorInformationHandle string
+	ErrorInformationHandle string `dmi:"Error Information Handle"`
 	//Number Of Devices: 2
-	NumberOfDevices string
-	MemoryList []*MemoryDevice
+	NumberOfDevices string `dmi:"Number Of Devices"`
+	DeviceCount     int    `dmi:"Number Of Devices"`
+	MemoryList      []*MemoryDevice
 }
 
 type MemoryDevice struct {
+	Handle  Handle            // DMI结构的句柄
+	DMIType int               // DMI type, dmidecode -q 的输出中为-1
+	Extra   map[string]string // 没有对应字段的key, 列表值用换行连接
+	Raw     map[string]string // 被替换的占位符和不在取值表中的枚举的原始值
 	//Array Handle: 0x0005
-	ArrayHandle string
+	ArrayHandle string `dmi:"Array Handle"`
 	//Error Information Handle: Not Provided
-	ErrorInformationHandle string
+	ErrorInformationHandle string `dmi:"Error Information Handle"`
 	//Total Width: 64 bits
-	TotalWidth string
+	TotalWidth     string `dmi:"Total Width"`
+	TotalWidthBits int    `dmi:"Total Width"`
 	//Data Width: 64 bits
-	DataWidth string
+	DataWidth     string `dmi:"Data Width"`
+	DataWidthBits int    `dmi:"Data Width"`
 	// Size: 4096 MB
-	Size string
+	Size      string `dmi:"Size"`
+	SizeBytes Size   `dmi:"Size"`
 	//Form Factor: SODIMM
-	FormFactor string
+	FormFactor FormFactor `dmi:"Form Factor"`
 	//Set: None
-	Set string
+	Set string `dmi:"Set"`
 	//Locator: ChannelB-DIMM0
-	Locator string
+	Locator string `dmi:"Locator,normalize"`
 	//Bank Locator: BANK 2
-	BankLocator string
+	BankLocator string `dmi:"Bank Locator,normalize"`
 	//Type: DDR3
-	Type string
+	Type MemoryType `dmi:"Type"`
 	//Type Detail: Synchronous
-	TypeDetail string
+	TypeDetail string `dmi:"Type Detail"`
 	//Speed: 1600 MHz
-	Speed string
+	Speed    string       `dmi:"Speed"`
+	SpeedMTs TransferRate `dmi:"Speed"`
 	//Manufacturer: Hynix/Hyundai
-	Manufacturer string
+	Manufacturer         string   `dmi:"Manufacturer,normalize"`
+	ManufacturerOptional Optional `dmi:"Manufacturer,normalize"`
 	//Serial Number: 1A6266B1
-	SerialNumber string
+	SerialNumber         string   `dmi:"Serial Number,normalize"`
+	SerialNumberOptional Optional `dmi:"Serial Number,normalize"`
 	//Asset Tag: 9876543210
-	AssetTag string
+	AssetTag         string   `dmi:"Asset Tag,normalize"`
+	AssetTagOptional Optional `dmi:"Asset Tag,normalize"`
 	//Part Number: HMT451S6AFR8A-PB
-	PartNumber string
+	PartNumber string `dmi:"Part Number,normalize"`
 	//Rank: Unknown
-	Rank string
-	//Configured Clock Speed: 1600 MHz
-	ConfiguredClockSpeed string
+	Rank  string `dmi:"Rank"`
+	Ranks int    `dmi:"Rank"`
+	//Configured Clock Speed: 1600 MHz, dmidecode 3.2 开始为 Configured Memory Speed: 2666 MT/s
+	ConfiguredClockSpeed string       `dmi:"Configured Memory Speed|Configured Clock Speed"`
+	ConfiguredSpeedMTs   TransferRate `dmi:"Configured Memory Speed|Configured Clock Speed"`
+
+	array *PhysicalMemoryArray
 }
 
 func (d *DmiDecode) QueryMemory() (*MemoryInfo, error) {
-	cmd := fmt.Sprintf("%s -t memory", d.Path)
-	if DEBUG {
-		log.Println("now query memory info: " + cmd)
-	}
-	memory, err := osutils.ExecuteCommand(cmd)
+	return d.QueryMemoryContext(context.Background())
+}
+
+func (d *DmiDecode) QueryMemoryContext(ctx context.Context) (*MemoryInfo, error) {
+	records, err := d.query(ctx, "memory")
 	if err != nil {
-		if DEBUG {
-			log.Println(err)
-		}
 		return nil, err
 	}
+	return memoryFromRecords(records), nil
+}
+
+// 多个Physical Memory Array时只保留最后一个的字段, MemoryList包括所有内存条, 每个内存条的Array()为所属的阵列
+func memoryFromRecords(records []Record) *MemoryInfo {
+	_, devices, _ := linkMemory(records)
+	return memoryInfo(records, devices)
+}
+
+// MemoryList为linkMemory返回的devices
+func memoryInfo(records []Record, devices []*MemoryDevice) *MemoryInfo {
 	var result *MemoryInfo = new(MemoryInfo)
-	result.MemoryList = make([]*MemoryDevice, 0)
-	memoryArray := strings.Split(memory, "\n\n")
-	for _, memoryInfo := range memoryArray {
-		if strings.Contains(memoryInfo, "\nPhysical Memory Array\n") {
-			re, _ := regexp.Compile("\n\t\t")
-			memoryInfo = re.ReplaceAllString(memoryInfo, "|")
-			re, _ = regexp.Compile("\n\t([^\n\t].*)")
-			memoryInfoArray := re.FindAllString(memoryInfo, -1)
-			for _, subMemoryInfo := range memoryInfoArray {
-				subMemoryInfoArray := strings.Split(subMemoryInfo, ":")
-				if len(subMemoryInfoArray) == 2 {
-					key := strings.TrimSpace(subMemoryInfoArray[0])
-					value := strings.TrimSpace(subMemoryInfoArray[1])
-					switch key {
-					case "Location":
-						result.Location = value
-					case "Use":
-						result.Use = value
-					case "Error Correction Type":
-						result.ErrorCorrectionType = value
-					case "Maximum Capacity":
-						result.MaximumCapacity = value
-					case "Error Information Handle":
-						result.ErrorInformationHandle = value
-					case "Number Of Devices":
-						result.NumberOfDevices = value
-					}
-				}
-			}
-		} else if strings.Contains(memoryInfo, "\nMemory Device\n") {
-			var memDevice *MemoryDevice = new(MemoryDevice)
-			re, _ := regexp.Compile("\n\t\t")
-			memoryInfo = re.ReplaceAllString(memoryInfo, "|")
-			re, _ = regexp.Compile("\n\t([^\n\t].*)")
-			memoryDeviceArray := re.FindAllString(memoryInfo, -1)
-			for _, subMemoryDevice := range memoryDeviceArray {
-				subMemoryDeviceArray := strings.Split(subMemoryDevice, ":")
-				if len(subMemoryDeviceArray) == 2 {
-					key := strings.TrimSpace(subMemoryDeviceArray[0])
-					value := strings.TrimSpace(subMemoryDeviceArray[1])
-					switch key {
-					case "Array Handle":
-						memDevice.ArrayHandle = value
-					case "Error Information Handle":
-						memDevice.ErrorInformationHandle = value
-					case "Total Width":
-						memDevice.TotalWidth = value
-					case "Data Width":
-						memDevice.DataWidth = value
-					case "Size":
-						memDevice.Size = value
-					case "Form Factor":
-						memDevice.FormFactor = value
-					case "Set":
-						memDevice.Set = value
-					case "Locator":
-						memDevice.Locator = value
-					case "Bank Locator":
-						memDevice.BankLocator = value
-					case "Type":
-						memDevice.Type = value
-					case "Type Detail":
-						memDevice.TypeDetail = value
-					case "Speed":
-						memDevice.Speed = value
-					case "Manufacturer":
-						memDevice.Manufacturer = value
-					case "Serial Number":
-						memDevice.SerialNumber = value
-					case "Asset Tag":
-						memDevice.AssetTag = value
-					case "Part Number":
-						memDevice.PartNumber = value
-					case "Rank":
-						memDevice.Rank = value
-					case "Configured Clock Speed":
-						memDevice.ConfiguredClockSpeed = value
-					}
-				}
-			}
-			result.MemoryList = append(result.MemoryList, memDevice)
+	for index := range records {
+		if records[index].Name == "Physical Memory Array" {
+			DecodeRecord(&records[index], result)
 		}
-
 	}
-	return result, nil
+	result.MemoryList = make([]*MemoryDevice, 0, len(devices))
+	result.MemoryList = append(result.MemoryList, devices...)
+	return result
+}
+
+func memoryDeviceFromRecord(record *Record) *MemoryDevice {
+	var memDevice *MemoryDevice = new(MemoryDevice)
+	DecodeRecord(record, memDevice)
+	return memDevice
 }
 
 // dmidecode -t cache
 type CacheInfo struct {
+	Handle  Handle            // DMI结构的句柄
+	DMIType int               // DMI type, dmidecode -q 的输出中为-1
+	Extra   map[string]string // 没有对应字段的key, 列表值用换行连接
+	Raw     map[string]string // 被替换的占位符和不在取值表中的枚举的原始值
 	//Socket Designation: L2-Cache
-	SocketDesignation string
+	SocketDesignation string `dmi:"Socket Designation,normalize"`
 	//Configuration: Enabled, Not Socketed, Level 2
-	Configuration string
+	Configuration string `dmi:"Configuration"`
 	//Operational Mode: Write Back
-	OperationalMode string
+	OperationalMode string `dmi:"Operational Mode"`
 	//Location: Internal
-	Location string
+	Location string `dmi:"Location"`
 	//Installed Size: 256 kB
-	InstalledSize string
+	InstalledSize      string `dmi:"Installed Size"`
+	InstalledSizeBytes Size   `dmi:"Installed Size"`
 	//Maximum Size: 256 kB
-	MaximumSize string
+	MaximumSize      string `dmi:"Maximum Size"`
+	MaximumSizeBytes Size   `dmi:"Maximum Size"`
 	//Supported SRAM Types:
-	SupportedSRAMTypes []string
+	SupportedSRAMTypes []string `dmi:"Supported SRAM Types"`
 	//Asynchronous
 	//Installed SRAM Type: Asynchronous
-	InstalledSRAMType string
+	InstalledSRAMType string `dmi:"Installed SRAM Type"`
 	//Speed: Unknown
-	Speed string
+	Speed string `dmi:"Speed"`
 	//Error Correction Type: Single-bit ECC
-	ErrorCorrectionType string
+	ErrorCorrectionType ErrorCorrectionType `dmi:"Error Correction Type"`
 	//System Type: Unified
-	SystemType string
+	SystemType string `dmi:"System Type"`
 	//Associativity: 8-way Set-associative
-	Associativity string
+	Associativity CacheAssociativity `dmi:"Associativity"`
 }
 
 func (d *DmiDecode) QueryCache() ([]*CacheInfo, error) {
-	cmd := fmt.Sprintf("%s -t cache", d.Path)
-	if DEBUG {
-		log.Println("now query cache info: " + cmd)
-	}
-	cache, err := osutils.ExecuteCommand(cmd)
+	return d.QueryCacheContext(context.Background())
+}
+
+func (d *DmiDecode) QueryCacheContext(ctx context.Context) ([]*CacheInfo, error) {
+	records, err := d.query(ctx, "cache")
 	if err != nil {
-		if DEBUG {
-			log.Println(err)
-		}
 		return nil, err
 	}
+	return cacheFromRecords(records), nil
+}
+
+func cacheFromRecords(records []Record) []*CacheInfo {
 	var result = make([]*CacheInfo, 0)
-	cacheArray := strings.Split(cache, "\n\n")
-	for _, cacheInfo := range cacheArray {
-		if strings.Contains(cacheInfo, "\nCache Information\n") {
-			re, _ := regexp.Compile("\n\t\t")
-			cacheInfo = re.ReplaceAllString(cacheInfo, "|")
-			re, _ = regexp.Compile("\n\t([^\n\t].*)")
-			cacheInfoArray := re.FindAllString(cacheInfo, -1)
-
-			var subCache *CacheInfo = new(CacheInfo)
-
-			for _, subCacheInfo := range cacheInfoArray {
-				subCacheInfoArray := strings.Split(subCacheInfo, ":")
-				if len(subCacheInfoArray) == 2 {
-					key := strings.TrimSpace(subCacheInfoArray[0])
-					value := strings.TrimSpace(subCacheInfoArray[1])
-					switch key {
-					case "Socket Designation":
-						subCache.SocketDesignation = value
-					case "Configuration":
-						subCache.Configuration = value
-					case "Operational Mode":
-						subCache.OperationalMode = value
-					case "Location":
-						subCache.Location = value
-					case "Installed Size":
-						subCache.InstalledSize = value
-					case "Maximum Size":
-						subCache.MaximumSize = value
-					case "Supported SRAM Types":
-						flagsArray := strings.Split(value, "|")
-						for index, subValue := range flagsArray {
-							flagsArray[index] = strings.TrimSpace(subValue)
-						}
-						subCache.SupportedSRAMTypes = flagsArray
-					case "Installed SRAM Type":
-						subCache.InstalledSRAMType = value
-					case "Speed":
-						subCache.Speed = value
-					case "Error Correction Type":
-						subCache.ErrorCorrectionType = value
-					case "System Type":
-						subCache.SystemType = value
-					case "Associativity":
-						subCache.Associativity = value
-					}
-				}
-			}
-			result = append(result, subCache)
+	for index := range records {
+		if records[index].Name != "Cache Information" {
+			continue
 		}
+		var subCache *CacheInfo = new(CacheInfo)
+		DecodeRecord(&records[index], subCache)
+		result = append(result, subCache)
 	}
-	return result, nil
+	return result
 }
 
 // dmidecode -t connector
 type PortConnectorInfo struct {
+	Handle  Handle            // DMI结构的句柄
+	DMIType int               // DMI type, dmidecode -q 的输出中为-1
+	Extra   map[string]string // 没有对应字段的key, 列表值用换行连接
+	Raw     map[string]string // 被替换的占位符和不在取值表中的枚举的原始值
 	//Internal Reference Designator: Not Available
-	InternalReferenceDesignator string
+	InternalReferenceDesignator string `dmi:"Internal Reference Designator,normalize"`
 	//Internal Connector Type: None
-	InternalConnectorType string
+	InternalConnectorType string `dmi:"Internal Connector Type"`
 	//External Reference Designator: External Monitor
-	ExternalReferenceDesignator string
+	ExternalReferenceDesignator string `dmi:"External Reference Designator,normalize"`
 	//External Connector Type: DB-15 female
-	ExternalConnectorType string
+	ExternalConnectorType string `dmi:"External Connector Type"`
 	//Port Type: Video Port
-	PortType string
+	PortType string `dmi:"Port Type"`
 }
 
 func (d *DmiDecode) QueryConnector() ([]*PortConnectorInfo, error) {
-	cmd := fmt.Sprintf("%s -t connector", d.Path)
-	if DEBUG {
-		log.Println("now query connector info: " + cmd)
-	}
-	connector, err := osutils.ExecuteCommand(cmd)
+	return d.QueryConnectorContext(context.Background())
+}
+
+func (d *DmiDecode) QueryConnectorContext(ctx context.Context) ([]*PortConnectorInfo, error) {
+	records, err := d.query(ctx, "connector")
 	if err != nil {
-		if DEBUG {
-			log.Println(err)
-		}
 		return nil, err
 	}
+	return connectorFromRecords(records), nil
+}
+
+func connectorFromRecords(records []Record) []*PortConnectorInfo {
 	var result = make([]*PortConnectorInfo, 0)
-	connectorArray := strings.Split(connector, "\n\n")
-	for _, connectorInfo := range connectorArray {
-		if strings.Contains(connectorInfo, "\nPort Connector Information\n") {
-			re, _ := regexp.Compile("\n\t\t")
-			connectorInfo = re.ReplaceAllString(connectorInfo, "|")
-			re, _ = regexp.Compile("\n\t([^\n\t].*)")
-			connectorInfoArray := re.FindAllString(connectorInfo, -1)
-
-			var subConnector *PortConnectorInfo = new(PortConnectorInfo)
-			for _, subConnectorInfo := range connectorInfoArray {
-				subConnectorInfoArray := strings.Split(subConnectorInfo, ":")
-				if len(subConnectorInfoArray) == 2 {
-					key := strings.TrimSpace(subConnectorInfoArray[0])
-					value := strings.TrimSpace(subConnectorInfoArray[1])
-					switch key {
-					case "Internal Reference Designator":
-						subConnector.InternalReferenceDesignator = value
-					case "Internal Connector Type":
-						subConnector.InternalConnectorType = value
-					case "External Reference Designator":
-						subConnector.ExternalReferenceDesignator = value
-					case "External Connector Type":
-						subConnector.ExternalConnectorType = value
-					case "Port Type":
-						subConnector.PortType = value
-					}
-				}
-			}
-			result = append(result, subConnector)
+	for index := range records {
+		if records[index].Name != "Port Connector Information" {
+			continue
 		}
+		var subConnector *PortConnectorInfo = new(PortConnectorInfo)
+		DecodeRecord(&records[index], subConnector)
+		result = append(result, subConnector)
 	}
-	return result, nil
+	return result
 }
 
 // dmidecode -t slot
 type SystemSlotInfo struct {
+	Handle  Handle            // DMI结构的句柄
+	DMIType int               // DMI type, dmidecode -q 的输出中为-1
+	Extra   map[string]string // 没有对应字段的key, 列表值用换行连接
+	Raw     map[string]string // 被替换的占位符和不在取值表中的枚举的原始值
 	//Designation: ExpressCard Slot
-	Designation string
+	Designation string `dmi:"Designation,normalize"`
 	//Type: x1 PCI Express
-	Type string
+	Type     SlotType     `dmi:"Type"`
+	BusWidth SlotBusWidth `dmi:"Type"`
 	//Current Usage: Available
-	CurrentUsage string
+	CurrentUsage string `dmi:"Current Usage"`
 	//Length: Other
-	Length string
+	Length string `dmi:"Length"`
 	//ID: 1
-	ID string
+	ID string `dmi:"ID"`
 	//Characteristics:Hot-plug devices are supported
-	Characteristics []string
+	Characteristics []string `dmi:"Characteristics"`
 	//Bus Address: 0000:00:00.0
-	BusAddress string
+	BusAddress string `dmi:"Bus Address"`
 }
 
 func (d *DmiDecode) QuerySlot() ([]*SystemSlotInfo, error) {
-	cmd := fmt.Sprintf("%s -t slot", d.Path)
-	if DEBUG {
-		log.Println("now query slot info: " + cmd)
-	}
-	slot, err := osutils.ExecuteCommand(cmd)
+	return d.QuerySlotContext(context.Background())
+}
+
+func (d *DmiDecode) QuerySlotContext(ctx context.Context) ([]*SystemSlotInfo, error) {
+	records, err := d.query(ctx, "slot")
 	if err != nil {
-		if DEBUG {
-			log.Println(err)
-		}
 		return nil, err
 	}
+	return slotFromRecords(records), nil
+}
+
+func slotFromRecords(records []Record) []*SystemSlotInfo {
 	var result = make([]*SystemSlotInfo, 0)
-	slotArray := strings.Split(slot, "\n\n")
-	for _, slotInfo := range slotArray {
-		if strings.Contains(slotInfo, "\nSystem Slot Information\n") {
-			re, _ := regexp.Compile("\n\t\t")
-			slotInfo = re.ReplaceAllString(slotInfo, "|")
-			re, _ = regexp.Compile("\n\t([^\n\t].*)")
-			slotInfoArray := re.FindAllString(slotInfo, -1)
-
-			var subSlot *SystemSlotInfo = new(SystemSlotInfo)
-			for _, subSlotInfo := range slotInfoArray {
-				subSlotInfoArray := strings.Split(subSlotInfo, ":")
-				if len(subSlotInfoArray) == 2 {
-					key := strings.TrimSpace(subSlotInfoArray[0])
-					value := strings.TrimSpace(subSlotInfoArray[1])
-					switch key {
-					case "Designation":
-						subSlot.Designation = value
-					case "Type":
-						subSlot.Type = value
-					case "Current Usage":
-						subSlot.CurrentUsage = value
-					case "Length":
-						subSlot.Length = value
-					case "Characteristics":
-						characterArray := strings.Split(value, "|")
-						for index, subValue := range characterArray {
-							characterArray[index] = strings.TrimSpace(subValue)
-						}
-						subSlot.Characteristics = characterArray
-					case "Bus Address":
-						subSlot.BusAddress = value
-					}
-				}
-			}
-			result = append(result, subSlot)
+	for index := range records {
+		if records[index].Name != "System Slot Information" {
+			continue
 		}
+		var subSlot *SystemSlotInfo = new(SystemSlotInfo)
+		DecodeRecord(&records[index], subSlot)
+		result = append(result, subSlot)
 	}
-	return result, nil
+	return result
 }
@@ -1,83 +1,165 @@
 package dmidecode
 
 import (
+	"reflect"
 	"testing"
-	"fmt"
 )
 
-func TestDmiDecode_QueryBIOS(t *testing.T) {
-	d := Instance()
-	d.SetPassword("xxxxxx")
-	biosPtr, lanPtr, err := d.QueryBIOS()
+// 用录制好的输出代替真实的dmidecode, 不需要root也不需要密码
+func replayInstance(t *testing.T) *DmiDecode {
+	output := []byte(readTestdata(t, "thinkpad.txt"))
+	replay := make(ReplayRunner)
+	for _, keyword := range []string{"bios", "system", "baseboard", "chassis", "processor", "memory", "cache", "connector", "slot"} {
+		replay["dmidecode -t "+keyword] = output
+	}
+	replay["dmidecode -t processor -t cache"] = output
+	replay["dmidecode -t baseboard -t chassis"] = output
+	// 不需要IsActive, 设置了Runner就不会降级到 /sys/class/dmi/id
+	return &DmiDecode{Path: "dmidecode", Runner: replay}
+}
 
+// 不是root时(IsActive为false)所有查询都使用设置的Runner
+func TestDmiDecode_RunnerPrecedence(t *testing.T) {
+	defer func(saved string) { fallbackRoot = saved }(fallbackRoot)
+	fallbackRoot = "testdata/dmiid"
+
+	d := replayInstance(t)
+	d.Runner.(ReplayRunner)["dmidecode"] = []byte(readTestdata(t, "thinkpad.txt"))
+	bios, _, err := d.QueryBIOS()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// /sys/class/dmi/id 中没有Handle
+	if bios.Handle != 0x0000 || bios.RomSize != "8192 kB" {
+		t.Errorf("bios: %+v", bios)
+	}
+	inventory, err := d.QueryAll()
 	if err != nil {
-		fmt.Println("failed")
-		return
-	} else {
-		fmt.Println(biosPtr.Characteristics)
-		fmt.Println(biosPtr.BIOSRevision)
-		fmt.Println(lanPtr.InstallableLanguagesNumber)
+		t.Fatal(err)
+	}
+	if len(inventory.Processors) != 1 || inventory.Chassis.Handle != 0x0003 {
+		t.Errorf("inventory: %+v", inventory)
+	}
+
+	// 没有dmidecode时仍然降级
+	d.Path = ""
+	board, err := d.QueryBaseBoard()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if board.DMIType != 2 || board.Handle != 0 || board.Features != nil {
+		t.Errorf("fallback baseboard: %+v", board)
+	}
+}
+
+func TestDmiDecode_QueryBIOS(t *testing.T) {
+	d := replayInstance(t)
+	bios, language, err := d.QueryBIOS()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bios.Vendor != "LENOVO" || bios.Version != "J4ET76WW(1.76)" || bios.ReleaseDate != "03/03/2015" ||
+		bios.BIOSRevision != "1.76" || bios.RomSize != "8192 kB" {
+		t.Errorf("bios: %+v", bios)
+	}
+	if len(bios.Characteristics) != 10 || bios.Characteristics[0] != "PCI is supported" || bios.Characteristics[9] != "UEFI is supported" {
+		t.Errorf("characteristics: %q", bios.Characteristics)
+	}
+	if language.InstallableLanguagesNumber != 3 || language.CurrentlyInstalledLanguage != "en-US" {
+		t.Errorf("language: %+v", language)
 	}
 }
 
 func TestDmiDecode_QuerySystem(t *testing.T) {
-	d := Instance()
-	d.SetPassword("xxxxxx")
+	d := replayInstance(t)
 	info, err := d.QuerySystem()
 	if err != nil {
-		fmt.Println("query system failed!")
-	} else {
-		fmt.Println(info.Manufacturer)
+		t.Fatal(err)
+	}
+	if info.Manufacturer != "LENOVO" || info.ProductName != "20ASEB3" || info.Version != "ThinkPad T440p" ||
+		info.SerialNumber != "PB01ABCD" || info.UUID != "3A5F3C81-51E1-11CB-8F39-A8B3CF5D1A8E" || info.Family != "ThinkPad T440p" {
+		t.Errorf("system: %+v", info)
 	}
 }
 
 func TestDmiDecode_QueryProcessor(t *testing.T) {
-	d := Instance()
-	d.SetPassword("xxxxxx")
+	d := replayInstance(t)
 	info, err := d.QueryProcessor()
 	if err != nil {
-		fmt.Println("query system failed!")
-	} else {
-		fmt.Println(info.CoreCount)
+		t.Fatal(err)
+	}
+	if info.SocketDesignation != "CPU Socket - U3E1" || info.Version != "Intel(R) Core(TM) i7-4712MQ CPU @ 2.30GHz" ||
+		info.CoreCount != "4" || info.ThreadCount != "8" || info.MaxSpeed != "2300 MHz" || info.Status != "Populated, Enabled" {
+		t.Errorf("processor: %+v", info)
+	}
+	if caches := info.Caches(); len(caches) != 3 || caches[2].SocketDesignation != "L3-Cache" {
+		t.Errorf("caches: %+v", caches)
 	}
 }
 
 func TestDmiDecode_QueryBaseBoard(t *testing.T) {
-	d := Instance()
-	d.SetPassword("xxxxxx")
+	d := replayInstance(t)
 	info, err := d.QueryBaseBoard()
 	if err != nil {
-		fmt.Println("query system failed!")
-	} else {
-		fmt.Println(info.Manufacturer)
+		t.Fatal(err)
+	}
+	if info.Manufacturer != "LENOVO" || info.ProductName != "20ASEB3" || info.SerialNumber != "ZZ0R958AGF4" ||
+		!reflect.DeepEqual(info.Features, []string{"Board is a hosting board", "Board is replaceable"}) {
+		t.Errorf("baseboard: %+v", info)
+	}
+	// thinkpad.txt中的Chassis Handle指向BIOS
+	if chassis := info.Chassis(); chassis != nil {
+		t.Errorf("chassis: %+v", chassis)
+	}
+
+	// Chassis()引用的type 3需要同时查询
+	replay := ReplayRunner{"dmidecode -t baseboard -t chassis": []byte(readTestdata(t, "colons/synthetic-supermicro.txt"))}
+	info, err = (&DmiDecode{Path: "dmidecode", IsActive: true, Runner: replay}).QueryBaseBoard()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if chassis := info.Chassis(); chassis == nil || chassis.Handle != 0x0003 || chassis.Manufacturer != "Supermicro" {
+		t.Errorf("chassis: %+v", chassis)
 	}
 }
 
 func TestDmiDecode_QueryCache(t *testing.T) {
-	d := Instance()
-	d.SetPassword("xxxxxx")
+	d := replayInstance(t)
 	info, err := d.QueryCache()
 	if err != nil {
-		fmt.Println("query system failed!")
-	} else {
-		fmt.Println(len(info))
-		for _,cache := range info {
-			fmt.Println(cache.SocketDesignation)
+		t.Fatal(err)
+	}
+	if len(info) != 3 {
+		t.Fatalf("cache: got %d, want 3", len(info))
+	}
+	for index, want := range []string{"L1-Cache", "L2-Cache", "L3-Cache"} {
+		if info[index].SocketDesignation != want {
+			t.Errorf("cache %d: got %q, want %q", index, info[index].SocketDesignation, want)
 		}
 	}
+	if info[2].InstalledSize != "6144 kB" {
+		t.Errorf("l3: %+v", info[2])
+	}
 }
 
 func TestDmiDecode_QueryMemory(t *testing.T) {
-	d := Instance()
-	d.SetPassword("xxxxxx")
+	d := replayInstance(t)
 	info, err := d.QueryMemory()
-	if err != nil {fmt.Println(info.MaximumCapacity)
-		fmt.Println("query system failed!")
-	} else {
-		fmt.Println(info.MaximumCapacity)
-		for _, cache := range info.MemoryList {
-			fmt.Println(cache.Size)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.MaximumCapacity != "16 GB" || info.NumberOfDevices != "2" || len(info.MemoryList) != 2 {
+		t.Fatalf("memory: %+v", info)
+	}
+	if first := info.MemoryList[0]; first.Size != "4096 MB" || first.Locator != "ChannelA-DIMM0" || first.Speed != "1600 MHz" {
+		t.Errorf("first device: %+v", first)
+	}
+	for _, device := range info.MemoryList {
+		if array := device.Array(); array == nil || array.Handle != 0x0005 {
+			t.Errorf("array of %s: %+v", device.Locator, array)
 		}
 	}
+	if second := info.MemoryList[1]; second.Size != "No Module Installed" || second.Locator != "ChannelB-DIMM0" {
+		t.Errorf("second device: %+v", second)
+	}
 }
-
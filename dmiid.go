@@ -0,0 +1,164 @@
+package dmidecode
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// 没有root权限时的降级方案: 读取内核导出的 /sys/class/dmi/id,
+// 其中 product_uuid, product_serial, board_serial, chassis_serial 只有root可读
+
+const sysfsDmiID = "sys/class/dmi/id"
+
+// 便于测试时模拟权限错误
+var readFile = ioutil.ReadFile
+
+type DmiID struct {
+	Root             string // 根目录, 为空时使用"/"
+	KeepPlaceholders bool   // 为true时保留占位符, 见DmiDecode.KeepPlaceholders
+}
+
+type dmiIDReader struct {
+	dir         string
+	keep        bool
+	unavailable []string          // 因权限不足无法读取的字段
+	raw         map[string]string // 被替换为空字符串的占位符
+	err         error
+}
+
+func (s *DmiID) reader() (*dmiIDReader, error) {
+	root := s.Root
+	if root == "" {
+		root = "/"
+	}
+	dir := filepath.Join(root, sysfsDmiID)
+	if _, err := os.Stat(dir); err != nil {
+		return nil, err
+	}
+	return &dmiIDReader{dir: dir, keep: s.KeepPlaceholders}, nil
+}
+
+// 读取文件内容, 文件不存在返回空字符串
+func (r *dmiIDReader) read(name, key string) string {
+	if r.err != nil {
+		return ""
+	}
+	content, err := readFile(filepath.Join(r.dir, name))
+	switch {
+	case err == nil:
+		value := strings.TrimSpace(string(content))
+		if !r.keep && value != "" && IsPlaceholder(value) {
+			r.raw = addExtra(r.raw, Field{Key: key, Value: value})
+			return ""
+		}
+		return value
+	case os.IsPermission(err):
+		r.unavailable = append(r.unavailable, key)
+	case !os.IsNotExist(err):
+		r.err = err
+	}
+	return ""
+}
+
+// 与read相同, 同时返回Optional, 无法读取时Valid为false
+func (r *dmiIDReader) optional(name, key string) (string, Optional) {
+	value := r.read(name, key)
+	raw := value
+	if original, ok := r.raw[key]; ok {
+		raw = original
+	}
+	return value, Optional{Value: value, Raw: raw, Valid: value != ""}
+}
+
+func (s *DmiID) QueryBIOS() (*BiosInfo, *BiosLanguage, error) {
+	r, err := s.reader()
+	if err != nil {
+		return nil, nil, err
+	}
+	result := new(BiosInfo)
+	result.DMIType = 0
+	result.Vendor = r.read("bios_vendor", "Vendor")
+	result.Version = r.read("bios_version", "Version")
+	result.ReleaseDate = r.read("bios_date", "Release Date")
+	result.BIOSRevision = r.read("bios_release", "BIOS Revision")
+	result.FirmwareRevision = r.read("ec_firmware_release", "Firmware Revision")
+	result.Released.UnmarshalText([]byte(result.ReleaseDate))
+	result.BIOSRevisionNumber.UnmarshalText([]byte(result.BIOSRevision))
+	result.FirmwareRevisionNumber.UnmarshalText([]byte(result.FirmwareRevision))
+	result.Unavailable = r.unavailable
+	result.Raw = r.raw
+	if r.err != nil {
+		return nil, nil, r.err
+	}
+	// 语言信息没有导出
+	return result, new(BiosLanguage), nil
+}
+
+func (s *DmiID) QuerySystem() (*SystemInfo, error) {
+	r, err := s.reader()
+	if err != nil {
+		return nil, err
+	}
+	result := new(SystemInfo)
+	result.DMIType = 1
+	result.Manufacturer, result.ManufacturerOptional = r.optional("sys_vendor", "Manufacturer")
+	result.ProductName = r.read("product_name", "Product Name")
+	result.Version = r.read("product_version", "Version")
+	result.SerialNumber, result.SerialNumberOptional = r.optional("product_serial", "Serial Number")
+	result.UUID = strings.ToUpper(r.read("product_uuid", "UUID"))
+	result.SystemUUID.UnmarshalText([]byte(result.UUID))
+	result.SKUNumber = r.read("product_sku", "SKU Number")
+	result.Family = r.read("product_family", "Family")
+	result.Unavailable = r.unavailable
+	result.Raw = r.raw
+	if r.err != nil {
+		return nil, r.err
+	}
+	return result, nil
+}
+
+func (s *DmiID) QueryBaseBoard() (*BaseBoardInfo, error) {
+	r, err := s.reader()
+	if err != nil {
+		return nil, err
+	}
+	result := new(BaseBoardInfo)
+	result.DMIType = 2
+	result.Manufacturer, result.ManufacturerOptional = r.optional("board_vendor", "Manufacturer")
+	result.ProductName = r.read("board_name", "Product Name")
+	result.Version = r.read("board_version", "Version")
+	result.SerialNumber, result.SerialNumberOptional = r.optional("board_serial", "Serial Number")
+	result.AssertTag, result.AssetTagOptional = r.optional("board_asset_tag", "Asset Tag")
+	result.Unavailable = r.unavailable
+	result.Raw = r.raw
+	if r.err != nil {
+		return nil, r.err
+	}
+	return result, nil
+}
+
+func (s *DmiID) QueryChassis() (*ChassisInfo, error) {
+	r, err := s.reader()
+	if err != nil {
+		return nil, err
+	}
+	result := new(ChassisInfo)
+	result.DMIType = 3
+	result.Manufacturer, result.ManufacturerOptional = r.optional("chassis_vendor", "Manufacturer")
+	// chassis_type 是数字, 不包含Lock位
+	if code, err := strconv.Atoi(r.read("chassis_type", "Type")); err == nil {
+		result.Type = ChassisType(code & 0x7F)
+	}
+	result.Version = r.read("chassis_version", "Version")
+	result.SerialNumber, result.SerialNumberOptional = r.optional("chassis_serial", "Serial Number")
+	result.AssertTag, result.AssetTagOptional = r.optional("chassis_asset_tag", "Asset Tag")
+	result.Unavailable = r.unavailable
+	result.Raw = r.raw
+	if r.err != nil {
+		return nil, r.err
+	}
+	return result, nil
+}
@@ -0,0 +1,70 @@
+package dmidecode
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestDmiID(t *testing.T) {
+	s := &DmiID{Root: "testdata/dmiid"}
+	bios, _, err := s.QueryBIOS()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bios.Vendor != "LENOVO" || bios.ReleaseDate != "03/03/2015" || bios.BIOSRevision != "1.76" || len(bios.Unavailable) != 0 {
+		t.Errorf("bios: %+v", bios)
+	}
+	system, err := s.QuerySystem()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if system.UUID != "3A5F3C81-51E1-11CB-8F39-A8B3CF5D1A8E" || system.SerialNumber != "PB01ABCD" {
+		t.Errorf("system: %+v", system)
+	}
+	chassis, err := s.QueryChassis()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if chassis.Type != ChassisNotebook || chassis.AssertTag != "" || chassis.Raw["Asset Tag"] != "No Asset Information" {
+		t.Errorf("chassis: %+v", chassis)
+	}
+}
+
+func TestDmiID_Unavailable(t *testing.T) {
+	defer func(saved func(string) ([]byte, error)) { readFile = saved }(readFile)
+	readFile = func(name string) ([]byte, error) {
+		switch filepath.Base(name) {
+		case "product_uuid", "product_serial", "board_serial":
+			return nil, &os.PathError{Op: "open", Path: name, Err: os.ErrPermission}
+		}
+		return ioutil.ReadFile(name)
+	}
+	s := &DmiID{Root: "testdata/dmiid"}
+	system, err := s.QuerySystem()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if system.UUID != "" || system.ProductName != "20ASEB3" {
+		t.Errorf("system: %+v", system)
+	}
+	if want := []string{"Serial Number", "UUID"}; !reflect.DeepEqual(system.Unavailable, want) {
+		t.Errorf("system unavailable: got %q, want %q", system.Unavailable, want)
+	}
+	board, err := s.QueryBaseBoard()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"Serial Number"}; !reflect.DeepEqual(board.Unavailable, want) {
+		t.Errorf("baseboard unavailable: got %q, want %q", board.Unavailable, want)
+	}
+}
+
+func TestDmiID_Missing(t *testing.T) {
+	s := &DmiID{Root: "testdata/missing"}
+	if _, err := s.QuerySystem(); err == nil {
+		t.Error("expected error for missing directory")
+	}
+}
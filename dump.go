@@ -0,0 +1,30 @@
+package dmidecode
+
+import (
+	"fmt"
+	"io/ioutil"
+)
+
+// dmidecode --dump-bin 生成的文件: 开头是入口点, 入口点中的表地址被改写为32,
+// 结构表从文件偏移32处开始
+
+// 解析 dmidecode --dump-bin 的内容
+func ParseDump(data []byte) (*Table, error) {
+	entry, err := ParseEntryPoint(data)
+	if err != nil {
+		return nil, err
+	}
+	if entry.TableAddress >= uint64(len(data)) {
+		return nil, fmt.Errorf("%v: table offset %d beyond end of dump", ErrTable, entry.TableAddress)
+	}
+	return ParseTable(data, data[entry.TableAddress:])
+}
+
+// 读取 dmidecode --dump-bin 生成的文件
+func ReadDump(path string) (*Table, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDump(data)
+}
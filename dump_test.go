@@ -0,0 +1,52 @@
+package dmidecode
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestReadDump(t *testing.T) {
+	sysfs, err := ReadSysfs("testdata/sysfs")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"dump-2.7.bin", "dump-3.2.bin"} {
+		table, err := ReadDump("testdata/" + name)
+		if err != nil {
+			t.Errorf("%s: %v", name, err)
+			continue
+		}
+		if table.EntryPoint.TableAddress != 32 {
+			t.Errorf("%s: table address %d", name, table.EntryPoint.TableAddress)
+		}
+		// 入口点的版本不同, 只比较结构的内容
+		records := table.Records()
+		for index := range records {
+			records[index].version = sysfs.EntryPoint.Version()
+		}
+		if !reflect.DeepEqual(records, sysfs.Records()) {
+			t.Errorf("%s: records differ from sysfs", name)
+		}
+	}
+}
+
+func TestReadDump_SM3(t *testing.T) {
+	table, err := ReadDump("testdata/dump-3.2.bin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if table.EntryPoint.Anchor != "_SM3_" || table.EntryPoint.String() != "SMBIOS 3.2.0" {
+		t.Errorf("entry point: %+v", table.EntryPoint)
+	}
+	memory, _ := table.QueryMemory()
+	if len(memory.MemoryList) != 2 || memory.MemoryList[0].Locator != "ChannelA-DIMM0" {
+		t.Errorf("memory: %+v", memory)
+	}
+}
+
+func TestParseDump_Truncated(t *testing.T) {
+	data := []byte(readTestdata(t, "dump-2.7.bin"))
+	if _, err := ParseDump(data[:32]); err == nil {
+		t.Error("expected error for dump without table")
+	}
+}
@@ -0,0 +1,520 @@
+package dmidecode
+
+import (
+	"fmt"
+	"strings"
+)
+
+// 与SMBIOS规范中的取值表对应的类型, 名称与dmidecode的输出一致,
+// 取值表见 smbios_tables.go
+
+// 不在取值表中的名称解析为OutOfSpec, 例如 chassis.Type == dmidecode.OutOfSpec,
+// String() 与dmidecode一致返回 "<OUT OF SPEC>"; 解码到结构体时原始值保存在Raw中
+const OutOfSpec = 0xFF
+
+// 不在取值表中的名称
+type OutOfSpecError struct {
+	Kind  string // 例如 "chassis type"
+	Value string
+}
+
+func (e *OutOfSpecError) Error() string {
+	return fmt.Sprintf("dmidecode: unknown %s %q", e.Kind, e.Value)
+}
+
+// 机箱类型, SMBIOS type 3 的 Type
+type ChassisType uint8
+
+const (
+	ChassisOther               ChassisType = 1
+	ChassisUnknown             ChassisType = 2
+	ChassisDesktop             ChassisType = 3
+	ChassisLowProfileDesktop   ChassisType = 4
+	ChassisPizzaBox            ChassisType = 5
+	ChassisMiniTower           ChassisType = 6
+	ChassisTower               ChassisType = 7
+	ChassisPortable            ChassisType = 8
+	ChassisLaptop              ChassisType = 9
+	ChassisNotebook            ChassisType = 10
+	ChassisHandHeld            ChassisType = 11
+	ChassisDockingStation      ChassisType = 12
+	ChassisAllInOne            ChassisType = 13
+	ChassisSubNotebook         ChassisType = 14
+	ChassisSpaceSaving         ChassisType = 15
+	ChassisLunchBox            ChassisType = 16
+	ChassisMainServerChassis   ChassisType = 17
+	ChassisExpansionChassis    ChassisType = 18
+	ChassisSubChassis          ChassisType = 19
+	ChassisBusExpansionChassis ChassisType = 20
+	ChassisPeripheralChassis   ChassisType = 21
+	ChassisRAIDChassis         ChassisType = 22
+	ChassisRackMountChassis    ChassisType = 23
+	ChassisSealedCasePC        ChassisType = 24
+	ChassisMultiSystem         ChassisType = 25
+	ChassisCompactPCI          ChassisType = 26
+	ChassisAdvancedTCA         ChassisType = 27
+	ChassisBlade               ChassisType = 28
+	ChassisBladeEnclosing      ChassisType = 29
+	ChassisTablet              ChassisType = 30
+	ChassisConvertible         ChassisType = 31
+	ChassisDetachable          ChassisType = 32
+	ChassisIoTGateway          ChassisType = 33
+	ChassisEmbeddedPC          ChassisType = 34
+	ChassisMiniPC              ChassisType = 35
+	ChassisStickPC             ChassisType = 36
+)
+
+// 笔记本, 平板等便携设备
+func (t ChassisType) IsPortable() bool {
+	switch t {
+	case ChassisPortable, ChassisLaptop, ChassisNotebook, ChassisHandHeld, ChassisSubNotebook,
+		ChassisTablet, ChassisConvertible, ChassisDetachable:
+		return true
+	}
+	return false
+}
+
+func (t ChassisType) String() string {
+	return enumString(chassisTypes, int(t))
+}
+
+// 解析dmidecode输出中的名称, 例如 "Notebook"
+func ParseChassisType(value string) (ChassisType, error) {
+	code, err := parseEnum(chassisTypeCodes, "chassis type", value)
+	return ChassisType(code), err
+}
+
+func (t *ChassisType) UnmarshalText(text []byte) error {
+	result, err := ParseChassisType(string(text))
+	*t = result
+	return err
+}
+
+// 内存类型, SMBIOS type 17 的 Type
+type MemoryType uint8
+
+const (
+	MemoryTypeOther                    MemoryType = 0x01
+	MemoryTypeUnknown                  MemoryType = 0x02
+	MemoryTypeDRAM                     MemoryType = 0x03
+	MemoryTypeEDRAM                    MemoryType = 0x04
+	MemoryTypeVRAM                     MemoryType = 0x05
+	MemoryTypeSRAM                     MemoryType = 0x06
+	MemoryTypeRAM                      MemoryType = 0x07
+	MemoryTypeROM                      MemoryType = 0x08
+	MemoryTypeFlash                    MemoryType = 0x09
+	MemoryTypeEEPROM                   MemoryType = 0x0A
+	MemoryTypeFEPROM                   MemoryType = 0x0B
+	MemoryTypeEPROM                    MemoryType = 0x0C
+	MemoryTypeCDRAM                    MemoryType = 0x0D
+	MemoryType3DRAM                    MemoryType = 0x0E
+	MemoryTypeSDRAM                    MemoryType = 0x0F
+	MemoryTypeSGRAM                    MemoryType = 0x10
+	MemoryTypeRDRAM                    MemoryType = 0x11
+	MemoryTypeDDR                      MemoryType = 0x12
+	MemoryTypeDDR2                     MemoryType = 0x13
+	MemoryTypeDDR2FBDIMM               MemoryType = 0x14
+	MemoryTypeDDR3                     MemoryType = 0x18
+	MemoryTypeFBD2                     MemoryType = 0x19
+	MemoryTypeDDR4                     MemoryType = 0x1A
+	MemoryTypeLPDDR                    MemoryType = 0x1B
+	MemoryTypeLPDDR2                   MemoryType = 0x1C
+	MemoryTypeLPDDR3                   MemoryType = 0x1D
+	MemoryTypeLPDDR4                   MemoryType = 0x1E
+	MemoryTypeLogicalNonVolatileDevice MemoryType = 0x1F
+	MemoryTypeHBM                      MemoryType = 0x20
+	MemoryTypeHBM2                     MemoryType = 0x21
+	MemoryTypeDDR5                     MemoryType = 0x22
+	MemoryTypeLPDDR5                   MemoryType = 0x23
+	MemoryTypeHBM3                     MemoryType = 0x24
+)
+
+func (t MemoryType) String() string {
+	return enumString(memoryTypes, int(t))
+}
+
+// 解析dmidecode输出中的名称, 例如 "DDR4"
+func ParseMemoryType(value string) (MemoryType, error) {
+	code, err := parseEnum(memoryTypeCodes, "memory type", value)
+	return MemoryType(code), err
+}
+
+func (t *MemoryType) UnmarshalText(text []byte) error {
+	result, err := ParseMemoryType(string(text))
+	*t = result
+	return err
+}
+
+// 内存条的形态, SMBIOS type 17 的 Form Factor
+type FormFactor uint8
+
+const (
+	FormFactorOther           FormFactor = 0x01
+	FormFactorUnknown         FormFactor = 0x02
+	FormFactorSIMM            FormFactor = 0x03
+	FormFactorSIP             FormFactor = 0x04
+	FormFactorChip            FormFactor = 0x05
+	FormFactorDIP             FormFactor = 0x06
+	FormFactorZIP             FormFactor = 0x07
+	FormFactorProprietaryCard FormFactor = 0x08
+	FormFactorDIMM            FormFactor = 0x09
+	FormFactorTSOP            FormFactor = 0x0A
+	FormFactorRowOfChips      FormFactor = 0x0B
+	FormFactorRIMM            FormFactor = 0x0C
+	FormFactorSODIMM          FormFactor = 0x0D
+	FormFactorSRIMM           FormFactor = 0x0E
+	FormFactorFBDIMM          FormFactor = 0x0F
+	FormFactorDie             FormFactor = 0x10
+	FormFactorCAMM            FormFactor = 0x11
+)
+
+func (t FormFactor) String() string {
+	return enumString(memoryFormFactors, int(t))
+}
+
+// 解析dmidecode输出中的名称, 例如 "SODIMM"
+func ParseFormFactor(value string) (FormFactor, error) {
+	code, err := parseEnum(formFactorCodes, "form factor", value)
+	return FormFactor(code), err
+}
+
+func (t *FormFactor) UnmarshalText(text []byte) error {
+	result, err := ParseFormFactor(string(text))
+	*t = result
+	return err
+}
+
+// 缓存的相联度, SMBIOS type 7 的 Associativity
+type CacheAssociativity uint8
+
+const (
+	AssociativityOther               CacheAssociativity = 1
+	AssociativityUnknown             CacheAssociativity = 2
+	AssociativityDirectMapped        CacheAssociativity = 3
+	Associativity2WaySetAssociative  CacheAssociativity = 4
+	Associativity4WaySetAssociative  CacheAssociativity = 5
+	AssociativityFullyAssociative    CacheAssociativity = 6
+	Associativity8WaySetAssociative  CacheAssociativity = 7
+	Associativity16WaySetAssociative CacheAssociativity = 8
+	Associativity12WaySetAssociative CacheAssociativity = 9
+	Associativity24WaySetAssociative CacheAssociativity = 10
+	Associativity32WaySetAssociative CacheAssociativity = 11
+	Associativity48WaySetAssociative CacheAssociativity = 12
+	Associativity64WaySetAssociative CacheAssociativity = 13
+	Associativity20WaySetAssociative CacheAssociativity = 14
+)
+
+func (t CacheAssociativity) String() string {
+	return enumString(cacheAssociativity, int(t))
+}
+
+// 解析dmidecode输出中的名称, 例如 "8-way Set-associative"
+func ParseCacheAssociativity(value string) (CacheAssociativity, error) {
+	code, err := parseEnum(cacheAssociativityCodes, "cache associativity", value)
+	return CacheAssociativity(code), err
+}
+
+func (t *CacheAssociativity) UnmarshalText(text []byte) error {
+	result, err := ParseCacheAssociativity(string(text))
+	*t = result
+	return err
+}
+
+// 纠错类型, SMBIOS type 7 和 type 16 的 Error Correction Type
+type ErrorCorrectionType uint8
+
+const (
+	ErrorCorrectionOther        ErrorCorrectionType = 1
+	ErrorCorrectionUnknown      ErrorCorrectionType = 2
+	ErrorCorrectionNone         ErrorCorrectionType = 3
+	ErrorCorrectionParity       ErrorCorrectionType = 4
+	ErrorCorrectionSingleBitECC ErrorCorrectionType = 5
+	ErrorCorrectionMultiBitECC  ErrorCorrectionType = 6
+	ErrorCorrectionCRC          ErrorCorrectionType = 7
+)
+
+func (t ErrorCorrectionType) String() string {
+	return enumString(memoryErrorCorrectionTypes, int(t))
+}
+
+// 解析dmidecode输出中的名称, 例如 "Multi-bit ECC"
+func ParseErrorCorrectionType(value string) (ErrorCorrectionType, error) {
+	code, err := parseEnum(errorCorrectionTypeCodes, "error correction type", value)
+	return ErrorCorrectionType(code), err
+}
+
+func (t *ErrorCorrectionType) UnmarshalText(text []byte) error {
+	result, err := ParseErrorCorrectionType(string(text))
+	*t = result
+	return err
+}
+
+// 唤醒方式, SMBIOS type 1 的 Wake-up Type
+type WakeUpType uint8
+
+const (
+	WakeUpOther           WakeUpType = 1
+	WakeUpUnknown         WakeUpType = 2
+	WakeUpAPMTimer        WakeUpType = 3
+	WakeUpModemRing       WakeUpType = 4
+	WakeUpLANRemote       WakeUpType = 5
+	WakeUpPowerSwitch     WakeUpType = 6
+	WakeUpPCIPME          WakeUpType = 7
+	WakeUpACPowerRestored WakeUpType = 8
+)
+
+func (t WakeUpType) String() string {
+	return enumString(wakeUpTypes, int(t))
+}
+
+// 解析dmidecode输出中的名称, 例如 "Power Switch"
+func ParseWakeUpType(value string) (WakeUpType, error) {
+	code, err := parseEnum(wakeUpTypeCodes, "wake up type", value)
+	return WakeUpType(code), err
+}
+
+func (t *WakeUpType) UnmarshalText(text []byte) error {
+	result, err := ParseWakeUpType(string(text))
+	*t = result
+	return err
+}
+
+// 板载设备类型, SMBIOS type 10 和 type 41 的 Type
+type OnboardDeviceType uint8
+
+const (
+	OnboardDeviceOther          OnboardDeviceType = 0x01
+	OnboardDeviceUnknown        OnboardDeviceType = 0x02
+	OnboardDeviceVideo          OnboardDeviceType = 0x03
+	OnboardDeviceSCSIController OnboardDeviceType = 0x04
+	OnboardDeviceEthernet       OnboardDeviceType = 0x05
+	OnboardDeviceTokenRing      OnboardDeviceType = 0x06
+	OnboardDeviceSound          OnboardDeviceType = 0x07
+	OnboardDevicePATAController OnboardDeviceType = 0x08
+	OnboardDeviceSATAController OnboardDeviceType = 0x09
+	OnboardDeviceSASController  OnboardDeviceType = 0x0A
+	OnboardDeviceWirelessLAN    OnboardDeviceType = 0x0B
+	OnboardDeviceBluetooth      OnboardDeviceType = 0x0C
+	OnboardDeviceWWAN           OnboardDeviceType = 0x0D
+	OnboardDeviceEMMC           OnboardDeviceType = 0x0E
+	OnboardDeviceNVMeController OnboardDeviceType = 0x0F
+	OnboardDeviceUFSController  OnboardDeviceType = 0x10
+)
+
+func (t OnboardDeviceType) String() string {
+	return enumString(onboardDeviceTypes, int(t))
+}
+
+// 解析dmidecode输出中的名称, 例如 "Ethernet"
+func ParseOnboardDeviceType(value string) (OnboardDeviceType, error) {
+	code, err := parseEnum(onboardDeviceTypeCodes, "onboard device type", value)
+	return OnboardDeviceType(code), err
+}
+
+func (t *OnboardDeviceType) UnmarshalText(text []byte) error {
+	result, err := ParseOnboardDeviceType(string(text))
+	*t = result
+	return err
+}
+
+// 插槽类型, SMBIOS type 9 的 Slot Type
+type SlotType uint8
+
+const (
+	SlotTypeOther                                        SlotType = 0x01
+	SlotTypeUnknown                                      SlotType = 0x02
+	SlotTypeISA                                          SlotType = 0x03
+	SlotTypeMCA                                          SlotType = 0x04
+	SlotTypeEISA                                         SlotType = 0x05
+	SlotTypePCI                                          SlotType = 0x06
+	SlotTypePCCardPCMCIA                                 SlotType = 0x07
+	SlotTypeVLB                                          SlotType = 0x08
+	SlotTypeProprietary                                  SlotType = 0x09
+	SlotTypeProcessorCard                                SlotType = 0x0A
+	SlotTypeProprietaryMemoryCard                        SlotType = 0x0B
+	SlotTypeIORiserCard                                  SlotType = 0x0C
+	SlotTypeNuBus                                        SlotType = 0x0D
+	SlotTypePCI66                                        SlotType = 0x0E
+	SlotTypeAGP                                          SlotType = 0x0F
+	SlotTypeAGP2x                                        SlotType = 0x10
+	SlotTypeAGP4x                                        SlotType = 0x11
+	SlotTypePCIX                                         SlotType = 0x12
+	SlotTypeAGP8x                                        SlotType = 0x13
+	SlotTypeM2Socket1DP                                  SlotType = 0x14
+	SlotTypeM2Socket1SD                                  SlotType = 0x15
+	SlotTypeM2Socket2                                    SlotType = 0x16
+	SlotTypeM2Socket3                                    SlotType = 0x17
+	SlotTypeMXMTypeI                                     SlotType = 0x18
+	SlotTypeMXMTypeII                                    SlotType = 0x19
+	SlotTypeMXMTypeIII                                   SlotType = 0x1A
+	SlotTypeMXMTypeIIIHE                                 SlotType = 0x1B
+	SlotTypeMXMTypeIV                                    SlotType = 0x1C
+	SlotTypeMXM30TypeA                                   SlotType = 0x1D
+	SlotTypeMXM30TypeB                                   SlotType = 0x1E
+	SlotTypePCIExpress2SFF8639U2                         SlotType = 0x1F
+	SlotTypePCIExpress3SFF8639U2                         SlotType = 0x20
+	SlotTypePCIExpressMini52PinWithBottomSideKeepOuts    SlotType = 0x21
+	SlotTypePCIExpressMini52PinWithoutBottomSideKeepOuts SlotType = 0x22
+	SlotTypePCIExpressMini76Pin                          SlotType = 0x23
+	SlotTypePCIExpress4SFF8639U2                         SlotType = 0x24
+	SlotTypePCIExpress5SFF8639U2                         SlotType = 0x25
+	SlotTypeOCPNIC30SmallFormFactorSFF                   SlotType = 0x26
+	SlotTypeOCPNIC30LargeFormFactorLFF                   SlotType = 0x27
+	SlotTypeOCPNICPriorTo30                              SlotType = 0x28
+	SlotTypeCXLFlexbus10                                 SlotType = 0x30
+	SlotTypePC98C20                                      SlotType = 0xA0
+	SlotTypePC98C24                                      SlotType = 0xA1
+	SlotTypePC98E                                        SlotType = 0xA2
+	SlotTypePC98LocalBus                                 SlotType = 0xA3
+	SlotTypePC98Card                                     SlotType = 0xA4
+	SlotTypePCIExpress                                   SlotType = 0xA5
+	SlotTypePCIExpressX1                                 SlotType = 0xA6
+	SlotTypePCIExpressX2                                 SlotType = 0xA7
+	SlotTypePCIExpressX4                                 SlotType = 0xA8
+	SlotTypePCIExpressX8                                 SlotType = 0xA9
+	SlotTypePCIExpressX16                                SlotType = 0xAA
+	SlotTypePCIExpress2                                  SlotType = 0xAB
+	SlotTypePCIExpress2X1                                SlotType = 0xAC
+	SlotTypePCIExpress2X2                                SlotType = 0xAD
+	SlotTypePCIExpress2X4                                SlotType = 0xAE
+	SlotTypePCIExpress2X8                                SlotType = 0xAF
+	SlotTypePCIExpress2X16                               SlotType = 0xB0
+	SlotTypePCIExpress3                                  SlotType = 0xB1
+	SlotTypePCIExpress3X1                                SlotType = 0xB2
+	SlotTypePCIExpress3X2                                SlotType = 0xB3
+	SlotTypePCIExpress3X4                                SlotType = 0xB4
+	SlotTypePCIExpress3X8                                SlotType = 0xB5
+	SlotTypePCIExpress3X16                               SlotType = 0xB6
+	SlotTypePCIExpress4                                  SlotType = 0xB8
+	SlotTypePCIExpress4X1                                SlotType = 0xB9
+	SlotTypePCIExpress4X2                                SlotType = 0xBA
+	SlotTypePCIExpress4X4                                SlotType = 0xBB
+	SlotTypePCIExpress4X8                                SlotType = 0xBC
+	SlotTypePCIExpress4X16                               SlotType = 0xBD
+	SlotTypePCIExpress5                                  SlotType = 0xBE
+	SlotTypePCIExpress5X1                                SlotType = 0xBF
+	SlotTypePCIExpress5X2                                SlotType = 0xC0
+	SlotTypePCIExpress5X4                                SlotType = 0xC1
+	SlotTypePCIExpress5X8                                SlotType = 0xC2
+	SlotTypePCIExpress5X16                               SlotType = 0xC3
+	SlotTypePCIExpress6Plus                              SlotType = 0xC4
+	SlotTypeEDSFFE1                                      SlotType = 0xC5
+	SlotTypeEDSFFE3                                      SlotType = 0xC6
+)
+
+func (t SlotType) String() string {
+	return enumString(slotTypes, int(t))
+}
+
+// 解析dmidecode输出中的名称, 例如 "PCI Express 3 x16";
+// dmidecode在类型前输出总线宽度, 例如 "x16 PCI Express 3 x16", 这里会去掉
+func ParseSlotType(value string) (SlotType, error) {
+	code, err := parseEnum(slotTypeCodes, "slot type", value)
+	if err != nil {
+		if width, rest := splitSlotWidth(value); width != 0 {
+			code, err = parseEnum(slotTypeCodes, "slot type", rest)
+		}
+	}
+	return SlotType(code), err
+}
+
+func (t *SlotType) UnmarshalText(text []byte) error {
+	result, err := ParseSlotType(string(text))
+	*t = result
+	return err
+}
+
+// 插槽的总线宽度, SMBIOS type 9 的 Slot Data Bus Width
+type SlotBusWidth uint8
+
+const (
+	SlotBusWidth8Bit   SlotBusWidth = 0x03
+	SlotBusWidth16Bit  SlotBusWidth = 0x04
+	SlotBusWidth32Bit  SlotBusWidth = 0x05
+	SlotBusWidth64Bit  SlotBusWidth = 0x06
+	SlotBusWidth128Bit SlotBusWidth = 0x07
+	SlotBusWidthX1     SlotBusWidth = 0x08
+	SlotBusWidthX2     SlotBusWidth = 0x09
+	SlotBusWidthX4     SlotBusWidth = 0x0A
+	SlotBusWidthX8     SlotBusWidth = 0x0B
+	SlotBusWidthX12    SlotBusWidth = 0x0C
+	SlotBusWidthX16    SlotBusWidth = 0x0D
+	SlotBusWidthX32    SlotBusWidth = 0x0E
+)
+
+func (t SlotBusWidth) String() string {
+	return enumString(slotBusWidths, int(t))
+}
+
+// 解析dmidecode输出中的名称, 例如 "x16"
+func ParseSlotBusWidth(value string) (SlotBusWidth, error) {
+	code, err := parseEnum(slotBusWidthCodes, "slot bus width", value)
+	return SlotBusWidth(code), err
+}
+
+// 从System Slot Information的Type中取出总线宽度, 例如 "x16 PCI Express 3 x16" 为x16, 没有宽度时为0
+func (t *SlotBusWidth) UnmarshalText(text []byte) error {
+	*t, _ = splitSlotWidth(string(text))
+	return nil
+}
+
+// 拆分dmidecode输出的插槽类型中的总线宽度
+func splitSlotWidth(value string) (SlotBusWidth, string) {
+	fields := strings.SplitN(strings.TrimSpace(value), " ", 2)
+	if len(fields) != 2 {
+		return 0, value
+	}
+	width, err := ParseSlotBusWidth(fields[0])
+	if err != nil {
+		return 0, value
+	}
+	return width, fields[1]
+}
+
+// 取值表中的名称, 0表示没有设置, 返回空字符串
+func enumString(table map[int]string, code int) string {
+	if code == 0 {
+		if name, ok := table[0]; ok {
+			return name
+		}
+		return ""
+	}
+	return strings.TrimSpace(lookup(table, code))
+}
+
+// 名称到取值的反查表, 初始化时生成
+var (
+	chassisTypeCodes         = enumCodes(chassisTypes)
+	memoryTypeCodes          = enumCodes(memoryTypes)
+	formFactorCodes          = enumCodes(memoryFormFactors)
+	cacheAssociativityCodes  = enumCodes(cacheAssociativity)
+	errorCorrectionTypeCodes = enumCodes(memoryErrorCorrectionTypes)
+	wakeUpTypeCodes          = enumCodes(wakeUpTypes)
+	onboardDeviceTypeCodes   = enumCodes(onboardDeviceTypes)
+	slotTypeCodes            = enumCodes(slotTypes)
+	slotBusWidthCodes        = enumCodes(slotBusWidths)
+)
+
+// 生成反查表, key为小写的名称, 多个取值同名时(例如Reserved)取最小的取值
+func enumCodes(table map[int]string) map[string]int {
+	result := make(map[string]int, len(table))
+	for code, name := range table {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if old, ok := result[name]; !ok || code < old {
+			result[name] = code
+		}
+	}
+	return result
+}
+
+// 按名称反查取值, 不区分大小写; 空字符串为0, 不在取值表中时返回OutOfSpec和*OutOfSpecError
+func parseEnum(codes map[string]int, kind, value string) (int, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, nil
+	}
+	if code, ok := codes[strings.ToLower(value)]; ok {
+		return code, nil
+	}
+	return OutOfSpec, &OutOfSpecError{Kind: kind, Value: value}
+}
@@ -0,0 +1,118 @@
+package dmidecode
+
+import "testing"
+
+func TestParseEnums(t *testing.T) {
+	for code, name := range chassisTypes {
+		parsed, err := ParseChassisType(name)
+		if err != nil || int(parsed) != code || parsed.String() != name {
+			t.Errorf("chassis %d %q: got %d %q %v", code, name, parsed, parsed, err)
+		}
+	}
+	for code, name := range memoryTypes {
+		if name == "Reserved" {
+			continue
+		}
+		if parsed, err := ParseMemoryType(name); err != nil || int(parsed) != code {
+			t.Errorf("memory type %d %q: got %d %v", code, name, parsed, err)
+		}
+	}
+	for code, name := range slotTypes {
+		if parsed, err := ParseSlotType(name); err != nil || int(parsed) != code {
+			t.Errorf("slot type %d %q: got %d %v", code, name, parsed, err)
+		}
+	}
+
+	tests := []struct {
+		got, want interface{}
+	}{
+		{mustParse(ParseFormFactor("SODIMM")), FormFactorSODIMM},
+		{mustParse(ParseCacheAssociativity("8-way Set-associative")), Associativity8WaySetAssociative},
+		{mustParse(ParseErrorCorrectionType("Multi-bit ECC")), ErrorCorrectionMultiBitECC},
+		{mustParse(ParseWakeUpType("power switch")), WakeUpPowerSwitch},
+		{mustParse(ParseSlotBusWidth("x16")), SlotBusWidthX16},
+	}
+	for _, test := range tests {
+		if test.got != test.want {
+			t.Errorf("got %v, want %v", test.got, test.want)
+		}
+	}
+
+	if chassis, err := ParseChassisType("Spaceship"); err == nil || chassis != OutOfSpec || chassis.String() != "<OUT OF SPEC>" {
+		t.Errorf("unknown chassis type: %d %v", chassis, err)
+	}
+	// 同名的取值取最小的
+	for i := 0; i < 10; i++ {
+		if parsed, _ := ParseMemoryType("Reserved"); parsed != 0x15 {
+			t.Fatalf("reserved: got %#x", uint8(parsed))
+		}
+	}
+	if parsed, err := ParseSlotType("CXL FLexbus 1.0"); err != nil || parsed != SlotTypeCXLFlexbus10 {
+		t.Errorf("cxl: got %d %v", parsed, err)
+	}
+	if parsed, err := ParseFormFactor("CAMM"); err != nil || parsed != FormFactorCAMM {
+		t.Errorf("camm: got %d %v", parsed, err)
+	}
+	if s := ChassisType(0).String(); s != "" {
+		t.Errorf("zero: %q", s)
+	}
+	if s := ChassisType(0x7E).String(); s != "<OUT OF SPEC>" {
+		t.Errorf("out of spec: %q", s)
+	}
+}
+
+func mustParse(value interface{}, err error) interface{} {
+	if err != nil {
+		return err
+	}
+	return value
+}
+
+func TestChassisType_IsPortable(t *testing.T) {
+	for _, chassis := range []ChassisType{ChassisLaptop, ChassisNotebook, ChassisTablet, ChassisConvertible} {
+		if !chassis.IsPortable() {
+			t.Errorf("%s should be portable", chassis)
+		}
+	}
+	for _, chassis := range []ChassisType{ChassisDesktop, ChassisRackMountChassis, ChassisUnknown, 0} {
+		if chassis.IsPortable() {
+			t.Errorf("%s should not be portable", chassis)
+		}
+	}
+}
+
+func TestSlotType_BusWidth(t *testing.T) {
+	slots := slotFromRecords(ParseRecords(readTestdata(t, "colons/synthetic-dell.txt")))
+	if slots[0].Type != SlotTypePCIExpress3X16 || slots[0].BusWidth != SlotBusWidthX16 {
+		t.Errorf("slot 1: %s %s", slots[0].BusWidth, slots[0].Type)
+	}
+	if slots[1].Type != SlotTypePCIExpress3X16 || slots[1].BusWidth != SlotBusWidthX8 {
+		t.Errorf("slot 2: %s %s", slots[1].BusWidth, slots[1].Type)
+	}
+	// 没有宽度前缀
+	var width SlotBusWidth
+	width.UnmarshalText([]byte("PCI Express x16"))
+	if width != 0 {
+		t.Errorf("width: %s", width)
+	}
+}
+
+func TestOutOfSpec(t *testing.T) {
+	records := ParseRecords(readTestdata(t, "thinkpad.txt"))
+	for index := range records {
+		if records[index].Name == "Chassis Information" {
+			records[index].Field("Type").Value = "Spaceship"
+		}
+	}
+	// Decode与Query的结果一致
+	var decoded ChassisInfo
+	if err := DecodeRecords(records, 3, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	chassis := chassisFromRecords(records)
+	for _, info := range []*ChassisInfo{&decoded, chassis} {
+		if info.Type != OutOfSpec || info.Raw["Type"] != "Spaceship" || info.Manufacturer != "LENOVO" {
+			t.Errorf("chassis: %+v", info)
+		}
+	}
+}
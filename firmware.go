@@ -0,0 +1,154 @@
+package dmidecode
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// 当前时间, 测试时替换
+var now = time.Now
+
+// BIOS的发布日期, 规范规定为 mm/dd/yy 或 mm/dd/yyyy, 解析失败时为零值
+type BIOSDate struct {
+	time.Time
+}
+
+func (d *BIOSDate) UnmarshalText(text []byte) error {
+	d.Time, _ = ParseBIOSDate(string(text))
+	return nil
+}
+
+func (d BIOSDate) String() string {
+	if d.IsZero() {
+		return ""
+	}
+	return d.Format("2006-01-02")
+}
+
+// 解析BIOS的发布日期, 除规范中的 mm/dd/yyyy 外还兼容:
+// 两位年份(规范规定为19yy, 但很多2000年以后的BIOS也只写两位, 小于80的按20yy处理),
+// yyyy/mm/dd, 用-或.分隔, yyyymmdd, 月和日写反(第一个数大于12), 以及日期后面的多余内容
+func ParseBIOSDate(value string) (time.Time, error) {
+	fields := strings.Fields(value)
+	if len(fields) == 0 {
+		return time.Time{}, fmt.Errorf("dmidecode: empty BIOS date")
+	}
+	text := strings.NewReplacer("-", "/", ".", "/").Replace(fields[0])
+
+	var parts []string
+	if len(text) == 8 && !strings.Contains(text, "/") && (strings.HasPrefix(text, "19") || strings.HasPrefix(text, "20")) {
+		parts = []string{text[:4], text[4:6], text[6:]}
+	} else {
+		parts = strings.Split(text, "/")
+	}
+	if len(parts) != 3 {
+		return time.Time{}, fmt.Errorf("dmidecode: malformed BIOS date %q", value)
+	}
+	numbers := make([]int, 3)
+	for index, part := range parts {
+		number, err := strconv.Atoi(part)
+		if err != nil || number < 0 {
+			return time.Time{}, fmt.Errorf("dmidecode: malformed BIOS date %q", value)
+		}
+		numbers[index] = number
+	}
+
+	var year, month, day int
+	if len(parts[0]) == 4 {
+		year, month, day = numbers[0], numbers[1], numbers[2]
+	} else {
+		month, day, year = numbers[0], numbers[1], numbers[2]
+		if month > 12 && day <= 12 {
+			month, day = day, month
+		}
+		if len(parts[2]) <= 2 {
+			if year < 80 {
+				year += 2000
+			} else {
+				year += 1900
+			}
+		}
+	}
+	result := time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+	// time.Date会把 02/30 规范化为 03/02, 这种日期是无效的
+	if year < 1980 || result.Year() != year || int(result.Month()) != month || result.Day() != day {
+		return time.Time{}, fmt.Errorf("dmidecode: invalid BIOS date %q", value)
+	}
+	return result, nil
+}
+
+// 距离BIOS发布的时间, 发布日期未知时返回false
+func (b *BiosInfo) Age() (time.Duration, bool) {
+	if b.Released.IsZero() {
+		return 0, false
+	}
+	return now().Sub(b.Released.Time), true
+}
+
+// BIOS是否发布于years年之前, 发布日期未知时返回false
+func (b *BiosInfo) OlderThan(years int) bool {
+	if b.Released.IsZero() {
+		return false
+	}
+	return b.Released.AddDate(years, 0, 0).Before(now())
+}
+
+// BIOS Revision 和 Firmware Revision, 由主次两个版本号组成, 例如 1.76
+type Revision struct {
+	Major int
+	Minor int
+}
+
+// 解析 "1.76" 形式的版本号
+func ParseRevision(value string) (Revision, error) {
+	parts := strings.SplitN(strings.TrimSpace(value), ".", 2)
+	if len(parts) != 2 {
+		return Revision{}, fmt.Errorf("dmidecode: malformed revision %q", value)
+	}
+	major, err := strconv.Atoi(parts[0])
+	if err != nil || major < 0 {
+		return Revision{}, fmt.Errorf("dmidecode: malformed revision %q", value)
+	}
+	minor, err := strconv.Atoi(parts[1])
+	if err != nil || minor < 0 {
+		return Revision{}, fmt.Errorf("dmidecode: malformed revision %q", value)
+	}
+	return Revision{Major: major, Minor: minor}, nil
+}
+
+// 无法解析时为零值
+func (r *Revision) UnmarshalText(text []byte) error {
+	*r, _ = ParseRevision(string(text))
+	return nil
+}
+
+func (r Revision) IsZero() bool {
+	return r.Major == 0 && r.Minor == 0
+}
+
+// 比较版本号, r小于, 等于, 大于other时分别返回-1, 0, 1; 次版本号按数字比较, 1.9小于1.10
+func (r Revision) Compare(other Revision) int {
+	switch {
+	case r.Major != other.Major:
+		if r.Major < other.Major {
+			return -1
+		}
+		return 1
+	case r.Minor < other.Minor:
+		return -1
+	case r.Minor > other.Minor:
+		return 1
+	}
+	return 0
+}
+
+// 是否不低于other
+func (r Revision) AtLeast(other Revision) bool {
+	return r.Compare(other) >= 0
+}
+
+func (r Revision) String() string {
+	return fmt.Sprintf("%d.%d", r.Major, r.Minor)
+}
@@ -0,0 +1,79 @@
+package dmidecode
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseBIOSDate(t *testing.T) {
+	for text, want := range map[string]string{
+		"03/03/2015":          "2015-03-03",
+		"3/3/2015":            "2015-03-03",
+		"12/25/08":            "2008-12-25",
+		"06/15/99":            "1999-06-15",
+		"25/12/2015":          "2015-12-25",
+		"2015/03/03":          "2015-03-03",
+		"2015-03-03":          "2015-03-03",
+		"03.03.2015":          "2015-03-03",
+		"20150303":            "2015-03-03",
+		"03/03/2015 10:20:30": "2015-03-03",
+	} {
+		date, err := ParseBIOSDate(text)
+		if err != nil {
+			t.Errorf("%q: %v", text, err)
+		} else if got := date.Format("2006-01-02"); got != want {
+			t.Errorf("%q: got %s, want %s", text, got, want)
+		}
+	}
+	for _, text := range []string{"", "Not Specified", "00/00/0000", "02/30/2015", "13/13/2015", "03/2015"} {
+		if date, err := ParseBIOSDate(text); err == nil {
+			t.Errorf("%q: got %v", text, date)
+		}
+	}
+}
+
+func TestBiosInfo_Age(t *testing.T) {
+	defer func(saved func() time.Time) { now = saved }(now)
+	now = func() time.Time { return time.Date(2020, 3, 4, 0, 0, 0, 0, time.UTC) }
+
+	bios, _ := biosFromRecords(ParseRecords(readTestdata(t, "thinkpad.txt")))
+	if bios.Released.String() != "2015-03-03" {
+		t.Errorf("released: %v", bios.Released)
+	}
+	if age, ok := bios.Age(); !ok || age != time.Date(2020, 3, 4, 0, 0, 0, 0, time.UTC).Sub(bios.Released.Time) {
+		t.Errorf("age: %v %v", age, ok)
+	}
+	if !bios.OlderThan(5) || bios.OlderThan(6) {
+		t.Errorf("older than: %v %v", bios.OlderThan(5), bios.OlderThan(6))
+	}
+
+	unknown := &BiosInfo{}
+	if _, ok := unknown.Age(); ok || unknown.OlderThan(0) {
+		t.Errorf("unknown release date: %+v", unknown)
+	}
+}
+
+func TestRevision(t *testing.T) {
+	bios, _ := biosFromRecords(ParseRecords(readTestdata(t, "thinkpad.txt")))
+	if bios.BIOSRevisionNumber != (Revision{1, 76}) || bios.FirmwareRevisionNumber != (Revision{1, 21}) || bios.FirmwareRevision != "1.21" {
+		t.Errorf("revision: %+v", bios)
+	}
+	if !bios.BIOSRevisionNumber.AtLeast(Revision{1, 9}) || bios.BIOSRevisionNumber.AtLeast(Revision{2, 0}) {
+		t.Errorf("compare: %v", bios.BIOSRevisionNumber)
+	}
+	for _, c := range []struct {
+		a, b string
+		want int
+	}{{"1.9", "1.10", -1}, {"2.0", "1.99", 1}, {"1.76", "1.76", 0}} {
+		a, _ := ParseRevision(c.a)
+		b, _ := ParseRevision(c.b)
+		if got := a.Compare(b); got != c.want {
+			t.Errorf("%s vs %s: got %d, want %d", c.a, c.b, got, c.want)
+		}
+	}
+	for _, text := range []string{"", "1", "a.b", "1.-2"} {
+		if _, err := ParseRevision(text); err == nil {
+			t.Errorf("%q: expected error", text)
+		}
+	}
+}
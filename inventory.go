@@ -0,0 +1,189 @@
+package dmidecode
+
+import (
+	"context"
+	"io"
+	"sort"
+)
+
+// 一次执行dmidecode得到的全部信息
+type Inventory struct {
+	BIOS         *BiosInfo
+	BIOSLanguage *BiosLanguage
+	System       *SystemInfo
+	BaseBoard    *BaseBoardInfo
+	Chassis      *ChassisInfo
+	Processors   []*ProcessorInfo
+	Memory       *MemoryInfo
+	MemoryArrays []*PhysicalMemoryArray
+	// Array Handle指向不存在的结构的内存条
+	UnattachedMemory []*MemoryDevice
+	Cache            []*CacheInfo
+	Connector        []*PortConnectorInfo
+	Slot             []*SystemSlotInfo
+	OnboardDevices   []*OnboardDevice
+	Batteries        []*PortableBattery
+	// 单位见ProbeValue
+	VoltageProbes     []*Probe
+	CoolingDevices    []*CoolingDevice
+	TemperatureProbes []*Probe
+	CurrentProbes     []*Probe
+	// OEM Strings和System Configuration Options, 按顺序
+	OEMStrings                 []string
+	SystemConfigurationOptions []string
+	// 指向不存在的结构的引用
+	Dangling []Reference
+}
+
+func inventoryFromRecords(records []Record) *Inventory {
+	result := new(Inventory)
+	result.BIOS, result.BIOSLanguage = biosFromRecords(records)
+	result.System = systemFromRecords(records)
+	result.BaseBoard = baseBoardFromRecords(records)
+	result.Chassis = chassisFromRecords(records)
+	// 每个结构只解码一次, 引用(Caches, Array, Chassis, TemperatureProbe)指向Inventory中的同一个对象
+	if chassis := result.BaseBoard.chassis; chassis != nil && chassis.Handle == result.Chassis.Handle {
+		result.BaseBoard.chassis = result.Chassis
+	}
+	result.Cache = cacheFromRecords(records)
+	result.Processors = linkProcessors(records, result.Cache)
+	var devices []*MemoryDevice
+	result.MemoryArrays, devices, result.UnattachedMemory = linkMemory(records)
+	result.Memory = memoryInfo(records, devices)
+	result.Connector = connectorFromRecords(records)
+	result.Slot = slotFromRecords(records)
+	result.OnboardDevices = onboardDevicesFromRecords(records)
+	result.Batteries = batteriesFromRecords(records)
+	result.VoltageProbes = probesFromRecords(records, "Voltage Probe")
+	result.TemperatureProbes = probesFromRecords(records, "Temperature Probe")
+	result.CoolingDevices = linkCoolingDevices(records, result.TemperatureProbes)
+	result.CurrentProbes = probesFromRecords(records, "Electrical Current Probe")
+	result.OEMStrings = oemStringsFromRecords(records)
+	result.SystemConfigurationOptions = configurationOptionsFromRecords(records)
+	result.Dangling = DanglingReferences(records)
+	return result
+}
+
+// 只执行一次不带参数的dmidecode, 解析出全部信息
+func (d *DmiDecode) QueryAll() (*Inventory, error) {
+	return d.QueryAllContext(context.Background())
+}
+
+func (d *DmiDecode) QueryAllContext(ctx context.Context) (*Inventory, error) {
+	if !d.active() {
+		return d.fallback().QueryAll()
+	}
+	records, err := d.query(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return inventoryFromRecords(records), nil
+}
+
+// 解析完整的dmidecode输出
+func ParseAll(r io.Reader) (*Inventory, error) {
+	records, err := ReadRecords(r)
+	if err != nil {
+		return nil, err
+	}
+	return inventoryFromRecords(records), nil
+}
+
+func (t *Table) QueryAll() (*Inventory, error) {
+	return inventoryFromRecords(t.Records()), nil
+}
+
+// /sys/class/dmi/id 中只有bios/system/baseboard/chassis, 其余为空
+func (s *DmiID) QueryAll() (*Inventory, error) {
+	result := new(Inventory)
+	var err error
+	if result.BIOS, result.BIOSLanguage, err = s.QueryBIOS(); err != nil {
+		return nil, err
+	}
+	if result.System, err = s.QuerySystem(); err != nil {
+		return nil, err
+	}
+	if result.BaseBoard, err = s.QueryBaseBoard(); err != nil {
+		return nil, err
+	}
+	if result.Chassis, err = s.QueryChassis(); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// 所有结果中没有对应字段的key, 格式为 "记录名: key", 排序去重,
+// 不为空时说明dmidecode新增了字段, 需要更新结构体
+func (i *Inventory) UnknownKeys() []string {
+	found := make(map[string]bool)
+	add := func(name string, extra map[string]string) {
+		for key := range extra {
+			found[name+": "+key] = true
+		}
+	}
+	if i.BIOS != nil {
+		add("BIOS Information", i.BIOS.Extra)
+	}
+	if i.BIOSLanguage != nil {
+		add("BIOS Language Information", i.BIOSLanguage.Extra)
+	}
+	if i.System != nil {
+		add("System Information", i.System.Extra)
+	}
+	if i.BaseBoard != nil {
+		add("Base Board Information", i.BaseBoard.Extra)
+	}
+	if i.Chassis != nil {
+		add("Chassis Information", i.Chassis.Extra)
+	}
+	for _, processor := range i.Processors {
+		add("Processor Information", processor.Extra)
+	}
+	for _, array := range i.MemoryArrays {
+		add("Physical Memory Array", array.Extra)
+		for _, device := range array.Devices {
+			add("Memory Device", device.Extra)
+		}
+	}
+	for _, device := range i.UnattachedMemory {
+		add("Memory Device", device.Extra)
+	}
+	for _, cache := range i.Cache {
+		add("Cache Information", cache.Extra)
+	}
+	for _, connector := range i.Connector {
+		add("Port Connector Information", connector.Extra)
+	}
+	for _, slot := range i.Slot {
+		add("System Slot Information", slot.Extra)
+	}
+	for _, device := range i.OnboardDevices {
+		add("Onboard Device", device.Extra)
+	}
+	for _, battery := range i.Batteries {
+		add("Portable Battery", battery.Extra)
+	}
+	for _, probe := range i.VoltageProbes {
+		add("Voltage Probe", probe.Extra)
+	}
+	for _, device := range i.CoolingDevices {
+		add("Cooling Device", device.Extra)
+	}
+	for _, probe := range i.TemperatureProbes {
+		add("Temperature Probe", probe.Extra)
+	}
+	for _, probe := range i.CurrentProbes {
+		add("Electrical Current Probe", probe.Extra)
+	}
+	result := make([]string, 0, len(found))
+	for key := range found {
+		result = append(result, key)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// 记录中没有对应字段的key, 见Inventory.UnknownKeys
+func UnknownKeys(records []Record) []string {
+	return inventoryFromRecords(records).UnknownKeys()
+}
@@ -0,0 +1,116 @@
+package dmidecode
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDmiDecode_QueryAll(t *testing.T) {
+	output := []byte(readTestdata(t, "thinkpad.txt"))
+	var calls [][]string
+	d := &DmiDecode{Path: "dmidecode", IsActive: true}
+	d.Runner = RunnerFunc(func(ctx context.Context, args []string) ([]byte, error) {
+		calls = append(calls, args)
+		return output, nil
+	})
+	inventory, err := d.QueryAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := [][]string{{"dmidecode"}}; !reflect.DeepEqual(calls, want) {
+		t.Errorf("calls: got %q, want %q", calls, want)
+	}
+	if inventory.BIOS.Vendor != "LENOVO" || inventory.BIOSLanguage.InstallableLanguagesNumber != 3 {
+		t.Errorf("bios: %+v %+v", inventory.BIOS, inventory.BIOSLanguage)
+	}
+	if inventory.System.Manufacturer != "LENOVO" || inventory.Chassis.Type != ChassisNotebook || inventory.BaseBoard.Manufacturer != "LENOVO" {
+		t.Errorf("system: %+v %+v %+v", inventory.System, inventory.BaseBoard, inventory.Chassis)
+	}
+	if len(inventory.Processors) != 1 || inventory.Processors[0].CoreCount != "4" || len(inventory.Memory.MemoryList) != 2 {
+		t.Errorf("processor/memory: %+v %+v", inventory.Processors, inventory.Memory)
+	}
+	if len(inventory.Cache) != 3 || len(inventory.Connector) != 2 || len(inventory.Slot) != 1 {
+		t.Errorf("cache/connector/slot: %d %d %d", len(inventory.Cache), len(inventory.Connector), len(inventory.Slot))
+	}
+
+	// 与单独查询的结果一致
+	slots, err := ParseSlot(strings.NewReader(string(output)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(inventory.Slot, slots) {
+		t.Errorf("slot: got %+v, want %+v", inventory.Slot, slots)
+	}
+}
+
+func TestTable_QueryAll(t *testing.T) {
+	table, err := ReadSysfs("testdata/sysfs")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fromTable, err := table.QueryAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	fromText, err := ParseAll(openTestdata(t, "thinkpad.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(fromTable.System, fromText.System) {
+		t.Errorf("memory: got %+v, want %+v", fromTable.System, fromText.System)
+	}
+}
+
+func TestDmiID_QueryAll(t *testing.T) {
+	inventory, err := (&DmiID{Root: "testdata/dmiid"}).QueryAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if inventory.BIOS.Vendor != "LENOVO" || inventory.Processors != nil || inventory.Memory != nil {
+		t.Errorf("inventory: %+v", inventory)
+	}
+}
+
+func TestUnknownKeys(t *testing.T) {
+	records := ParseRecords(readTestdata(t, "server-memory.txt"))
+	want := []string{
+		"Memory Device: Configured Voltage",
+		"Memory Device: Maximum Voltage",
+		"Memory Device: Minimum Voltage",
+	}
+	if got := UnknownKeys(records); !reflect.DeepEqual(got, want) {
+		t.Errorf("unknown: got %q, want %q", got, want)
+	}
+	arrays := memoryArraysFromRecords(records)
+	if extra := arrays[0].Devices[0].Extra; extra["Configured Voltage"] != "1.2 V" {
+		t.Errorf("extra: %q", extra)
+	}
+
+	// 列表值用换行连接
+	record := Record{Name: "Chassis Information", Fields: []Field{{Key: "Future List", List: []string{"a: 1", "b"}}}}
+	if extra := chassisFromRecords([]Record{record}).Extra; extra["Future List"] != "a: 1\nb" {
+		t.Errorf("extra: %q", extra)
+	}
+}
+
+func TestDmiDecode_Unknown(t *testing.T) {
+	d := replayInstance(t)
+	d.Runner = ReplayRunner{"dmidecode -t memory": []byte(readTestdata(t, "server-memory.txt"))}
+	var reported []string
+	d.Unknown = func(keys []string) {
+		reported = append(reported, keys...)
+	}
+	arrays, err := d.QueryMemoryArrays()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"Memory Device: Configured Voltage", "Memory Device: Maximum Voltage", "Memory Device: Minimum Voltage"}
+	if !reflect.DeepEqual(reported, want) {
+		t.Errorf("reported: got %q, want %q", reported, want)
+	}
+	if arrays[0].Devices[0].Extra["Configured Voltage"] != "1.2 V" {
+		t.Errorf("extra: %q", arrays[0].Devices[0].Extra)
+	}
+}
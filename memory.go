@@ -0,0 +1,160 @@
+package dmidecode
+
+import (
+	"context"
+	"io"
+)
+
+// 一个Physical Memory Array(DMI type 16)及其下的Memory Device(DMI type 17)
+// 多路服务器每个处理器一个, flash和NVDIMM也可能单独一个
+type PhysicalMemoryArray struct {
+	Handle  Handle            // DMI结构的句柄
+	DMIType int               // DMI type, dmidecode -q 的输出中为-1
+	Extra   map[string]string // 没有对应字段的key, 列表值用换行连接
+	Raw     map[string]string // 被替换的占位符和不在取值表中的枚举的原始值
+	//Location: System Board Or Motherboard
+	Location string `dmi:"Location"`
+	//Use: System Memory
+	Use string `dmi:"Use"`
+	//Error Correction Type: None
+	ErrorCorrectionType ErrorCorrectionType `dmi:"Error Correction Type"`
+	//Maximum Capacity: 16 GB
+	MaximumCapacity      string `dmi:"Maximum Capacity"`
+	MaximumCapacityBytes Size   `dmi:"Maximum Capacity"`
+	//Error Information Handle: Not Provided
+	ErrorInformationHandle string `dmi:"Error Information Handle"`
+	//Number Of Devices: 2
+	NumberOfDevices string `dmi:"Number Of Devices"`
+	DeviceCount     int    `dmi:"Number Of Devices"`
+	// Array Handle 等于Handle的Memory Device
+	Devices []*MemoryDevice
+}
+
+func (a *PhysicalMemoryArray) addDevice(device *MemoryDevice) {
+	device.array = a
+	a.Devices = append(a.Devices, device)
+}
+
+// 内存条所在的Physical Memory Array, Array Handle指向不存在的结构时返回nil
+func (m *MemoryDevice) Array() *PhysicalMemoryArray {
+	return m.array
+}
+
+// 插槽数, 优先使用Number Of Devices
+func (a *PhysicalMemoryArray) Slots() int {
+	if a.DeviceCount > 0 {
+		return a.DeviceCount
+	}
+	return len(a.Devices)
+}
+
+// 已安装内存的插槽数
+func (a *PhysicalMemoryArray) PopulatedSlots() int {
+	var result int
+	for _, device := range a.Devices {
+		if device.SizeBytes > 0 {
+			result++
+		}
+	}
+	return result
+}
+
+// 已安装内存的总大小, 单位字节
+func (a *PhysicalMemoryArray) InstalledSize() uint64 {
+	return uint64(a.InstalledSizeBytes())
+}
+
+// 与InstalledSize相同, 返回Size
+func (a *PhysicalMemoryArray) InstalledSizeBytes() Size {
+	var result Size
+	for _, device := range a.Devices {
+		result += device.SizeBytes
+	}
+	return result
+}
+
+// Maximum Capacity, 单位字节, 无法解析时返回0
+func (a *PhysicalMemoryArray) MaximumSize() uint64 {
+	return uint64(a.MaximumCapacityBytes)
+}
+
+// Array Handle指向不存在的结构的内存条不属于任何阵列, 可以从QueryMemory的MemoryList(Array()为nil)
+// 或QueryAll的Inventory.UnattachedMemory得到
+func (d *DmiDecode) QueryMemoryArrays() ([]*PhysicalMemoryArray, error) {
+	return d.QueryMemoryArraysContext(context.Background())
+}
+
+func (d *DmiDecode) QueryMemoryArraysContext(ctx context.Context) ([]*PhysicalMemoryArray, error) {
+	records, err := d.query(ctx, "memory")
+	if err != nil {
+		return nil, err
+	}
+	return memoryArraysFromRecords(records), nil
+}
+
+func ParseMemoryArrays(r io.Reader) ([]*PhysicalMemoryArray, error) {
+	records, err := ReadRecords(r)
+	if err != nil {
+		return nil, err
+	}
+	return memoryArraysFromRecords(records), nil
+}
+
+func (t *Table) QueryMemoryArrays() ([]*PhysicalMemoryArray, error) {
+	return memoryArraysFromRecords(t.Records()), nil
+}
+
+func memoryArraysFromRecords(records []Record) []*PhysicalMemoryArray {
+	arrays, _, _ := linkMemory(records)
+	return arrays
+}
+
+// 按Array Handle把Memory Device分到对应的Physical Memory Array, devices按出现的顺序返回全部内存条.
+// dmidecode -q 的输出没有handle, 此时归到前面最近的一个Physical Memory Array;
+// 找不到所属阵列的内存条放在unattached中
+func linkMemory(records []Record) (arrays []*PhysicalMemoryArray, devices, unattached []*MemoryDevice) {
+	byHandle := make(map[Handle]*PhysicalMemoryArray)
+	for index := range records {
+		record := &records[index]
+		if record.Name == "Physical Memory Array" {
+			array := memoryArrayFromRecord(record)
+			arrays = append(arrays, array)
+			if record.Type >= 0 {
+				byHandle[array.Handle] = array
+			}
+		}
+	}
+	// dmidecode -q 的输出中前面最近的一个Physical Memory Array
+	var last *PhysicalMemoryArray
+	var arrayIndex int
+	for index := range records {
+		record := &records[index]
+		switch record.Name {
+		case "Physical Memory Array":
+			last = arrays[arrayIndex]
+			arrayIndex++
+		case "Memory Device":
+			device := memoryDeviceFromRecord(record)
+			devices = append(devices, device)
+			array := last
+			if record.Type >= 0 {
+				array = nil
+				if handle, ok := parseHandle(device.ArrayHandle); ok {
+					array = byHandle[handle]
+				}
+			}
+			if array != nil {
+				array.addDevice(device)
+			} else {
+				unattached = append(unattached, device)
+			}
+		}
+	}
+	return arrays, devices, unattached
+}
+
+func memoryArrayFromRecord(record *Record) *PhysicalMemoryArray {
+	var result *PhysicalMemoryArray = new(PhysicalMemoryArray)
+	DecodeRecord(record, result)
+	return result
+}
@@ -0,0 +1,88 @@
+package dmidecode
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseMemoryArrays(t *testing.T) {
+	arrays, err := ParseMemoryArrays(openTestdata(t, "server-memory.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(arrays) != 3 {
+		t.Fatalf("arrays: got %d, want 3", len(arrays))
+	}
+	tests := []struct {
+		handle    Handle
+		use       string
+		locators  []string
+		populated int
+		installed Size
+		maximum   Size
+	}{
+		{0x1000, "System Memory", []string{"CPU1_DIMM_A1", "CPU1_DIMM_A2"}, 1, 32 << 30, 768 << 30},
+		{0x1001, "System Memory", []string{"CPU2_DIMM_A1", "CPU2_DIMM_A2"}, 2, 64 << 30, 768 << 30},
+		{0x1002, "Flash Memory", []string{"BIOS ROM"}, 1, 64 << 20, 64 << 20},
+	}
+	for index, test := range tests {
+		array := arrays[index]
+		if array.Handle != test.handle || array.Use != test.use {
+			t.Errorf("array %d: got %s %q, want %s %q", index, array.Handle, array.Use, test.handle, test.use)
+		}
+		var locators []string
+		for _, device := range array.Devices {
+			locators = append(locators, device.Locator)
+		}
+		if strings.Join(locators, ",") != strings.Join(test.locators, ",") {
+			t.Errorf("array %s devices: got %v, want %v", array.Handle, locators, test.locators)
+		}
+		if array.Slots() != len(test.locators) || array.PopulatedSlots() != test.populated {
+			t.Errorf("array %s slots: got %d/%d, want %d/%d", array.Handle,
+				array.PopulatedSlots(), array.Slots(), test.populated, len(test.locators))
+		}
+		if array.InstalledSizeBytes() != test.installed || array.MaximumCapacityBytes != test.maximum {
+			t.Errorf("array %s size: got %s/%s, want %s/%s", array.Handle,
+				array.InstalledSizeBytes(), array.MaximumCapacityBytes, test.installed, test.maximum)
+		}
+		if array.InstalledSize() != uint64(test.installed) || array.MaximumSize() != uint64(test.maximum) {
+			t.Errorf("array %s size: got %d/%d", array.Handle, array.InstalledSize(), array.MaximumSize())
+		}
+	}
+}
+
+func TestParseMemoryArrays_Quiet(t *testing.T) {
+	text := readTestdata(t, "server-memory.txt")
+	var lines []string
+	for _, line := range strings.Split(text, "\n") {
+		if !strings.HasPrefix(line, "Handle ") {
+			lines = append(lines, line)
+		}
+	}
+	arrays, err := ParseMemoryArrays(strings.NewReader(strings.Join(lines, "\n")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(arrays) != 3 || len(arrays[0].Devices) != 2 || len(arrays[1].Devices) != 2 || len(arrays[2].Devices) != 2 {
+		t.Errorf("arrays: %+v", arrays)
+	}
+}
+
+// Array Handle指向不存在的结构的内存条不能丢掉
+func TestUnattachedMemory(t *testing.T) {
+	inventory, err := ParseAll(openTestdata(t, "server-memory.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(inventory.UnattachedMemory) != 1 || inventory.UnattachedMemory[0].Handle != 0x1105 || inventory.UnattachedMemory[0].Array() != nil {
+		t.Errorf("unattached: %+v", inventory.UnattachedMemory)
+	}
+	if len(inventory.Memory.MemoryList) != 6 {
+		t.Errorf("memory list: got %d devices, want 6", len(inventory.Memory.MemoryList))
+	}
+	for _, device := range inventory.Memory.MemoryList {
+		if (device.Array() == nil) != (device.Handle == 0x1105) {
+			t.Errorf("device %s: array %+v", device.Handle, device.Array())
+		}
+	}
+}
@@ -0,0 +1,183 @@
+package dmidecode
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// PCI地址不属于任何type 41的板载设备或有ID的插槽
+var ErrNoNICName = errors.New("dmidecode: no firmware-derived name for PCI address")
+
+// 由SMBIOS的板载设备和插槽推算的网卡名
+type NICName struct {
+	Address     string   // PCI总线地址, 例如 0000:19:00.0
+	BiosDevName string   // biosdevname的命名, 板载为 em<Type Instance>, 插槽为 p<ID>p<端口>
+	Systemd     string   // systemd的命名, 板载为 eno<Type Instance>, 插槽为 ens<ID>[f<function>]
+	Interfaces  []string // sysfs中当前的网卡名, 没有读取sysfs时为空
+}
+
+// 根据type 41和type 9推算网卡名.
+// 插槽中的端口号为function+1; systemd在多功能设备上加f<function>, 是否为多功能设备根据sysfs中同一设备的其他function判断,
+// 不读取sysfs时只有function大于0才加
+type NICNamer struct {
+	Onboard []*OnboardDevice
+	Slots   []*SystemSlotInfo
+	// sysfs所在的根目录, 例如"/", 为空时只根据SMBIOS推算
+	Root string
+}
+
+func (i *Inventory) NICNamer(root string) *NICNamer {
+	return &NICNamer{Onboard: i.OnboardDevices, Slots: i.Slot, Root: root}
+}
+
+// 总线地址对应的网卡名, 没有对应的板载设备或插槽时返回ErrNoNICName
+func (n *NICNamer) Names(address string) (*NICName, error) {
+	normalized, err := NormalizePCIAddress(address)
+	if err != nil {
+		return nil, err
+	}
+	result := &NICName{Address: normalized}
+	if device := n.onboardDevice(normalized); device != nil {
+		result.BiosDevName = fmt.Sprintf("em%d", device.TypeInstance)
+		result.Systemd = fmt.Sprintf("eno%d", device.TypeInstance)
+	} else if slot, id := n.slot(normalized); slot != nil {
+		function := normalized[len(normalized)-1:]
+		port, _ := strconv.Atoi(function)
+		result.BiosDevName = fmt.Sprintf("p%dp%d", id, port+1)
+		result.Systemd = fmt.Sprintf("ens%d", id)
+		if port > 0 || n.multifunction(normalized) {
+			result.Systemd += "f" + function
+		}
+	} else {
+		return nil, ErrNoNICName
+	}
+	if n.Root != "" {
+		if device, err := ResolvePCIDevice(n.Root, normalized); err == nil {
+			result.Interfaces = device.Interfaces
+		}
+	}
+	return result, nil
+}
+
+// 本机所有网卡的命名, 按总线地址排序.
+// 板载设备取类型为Ethernet和Wireless LAN的; 插槽在读取sysfs时取其中所有的网络设备,
+// 否则取Current Usage为In Use的插槽的Bus Address
+func (n *NICNamer) Mapping() ([]*NICName, error) {
+	addresses := make(map[string]bool)
+	for _, device := range n.Onboard {
+		if !isNetworkDevice(device) {
+			continue
+		}
+		if address, err := NormalizePCIAddress(device.BusAddress); err == nil && device.TypeInstance > 0 {
+			addresses[address] = true
+		}
+	}
+	for _, slot := range n.Slots {
+		address, err := NormalizePCIAddress(slot.BusAddress)
+		if err != nil {
+			continue
+		}
+		if n.Root == "" {
+			if slot.CurrentUsage == "In Use" {
+				addresses[address] = true
+			}
+			continue
+		}
+		functions, err := n.functions(address)
+		if err != nil {
+			return nil, err
+		}
+		for _, function := range functions {
+			if n.isNetwork(function) {
+				addresses[function] = true
+			}
+		}
+	}
+
+	sorted := make([]string, 0, len(addresses))
+	for address := range addresses {
+		sorted = append(sorted, address)
+	}
+	sort.Strings(sorted)
+	var result []*NICName
+	for _, address := range sorted {
+		names, err := n.Names(address)
+		if err == ErrNoNICName {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, names)
+	}
+	return result, nil
+}
+
+// 地址对应的板载网卡, Type Instance按设备类型计数, 显卡, SATA控制器等不参与命名
+func (n *NICNamer) onboardDevice(address string) *OnboardDevice {
+	for _, device := range n.Onboard {
+		if device.TypeInstance == 0 || !isNetworkDevice(device) {
+			continue
+		}
+		if normalized, err := NormalizePCIAddress(device.BusAddress); err == nil && normalized == address {
+			return device
+		}
+	}
+	return nil
+}
+
+func isNetworkDevice(device *OnboardDevice) bool {
+	return device.Type == OnboardDeviceEthernet || device.Type == OnboardDeviceWirelessLAN
+}
+
+// 与地址的segment, bus, device相同且ID为数字的插槽
+func (n *NICNamer) slot(address string) (*SystemSlotInfo, int) {
+	for _, slot := range n.Slots {
+		normalized, err := NormalizePCIAddress(slot.BusAddress)
+		if err != nil || pciDevicePrefix(normalized) != pciDevicePrefix(address) {
+			continue
+		}
+		if id, err := strconv.Atoi(slot.ID); err == nil && id > 0 {
+			return slot, id
+		}
+	}
+	return nil, 0
+}
+
+// 去掉function, 例如 0000:3b:00
+func pciDevicePrefix(address string) string {
+	return address[:strings.LastIndex(address, ".")]
+}
+
+// sysfs中与address属于同一设备的所有function
+func (n *NICNamer) functions(address string) ([]string, error) {
+	matches, err := filepath.Glob(filepath.Join(n.Root, "sys/bus/pci/devices", pciDevicePrefix(address)+".*"))
+	if err != nil {
+		return nil, err
+	}
+	var result []string
+	for _, match := range matches {
+		result = append(result, filepath.Base(match))
+	}
+	sort.Strings(result)
+	return result, nil
+}
+
+func (n *NICNamer) multifunction(address string) bool {
+	if n.Root == "" {
+		return false
+	}
+	functions, err := n.functions(address)
+	return err == nil && len(functions) > 1
+}
+
+// PCI class 0x02xxxx 为网络控制器
+func (n *NICNamer) isNetwork(address string) bool {
+	content, err := ioutil.ReadFile(filepath.Join(n.Root, "sys/bus/pci/devices", address, "class"))
+	return err == nil && strings.HasPrefix(strings.TrimSpace(string(content)), "0x02")
+}
@@ -0,0 +1,79 @@
+package dmidecode
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func onboardInventory(t *testing.T) *Inventory {
+	inventory, err := ParseAll(strings.NewReader(readTestdata(t, "onboard/dmidecode.txt")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return inventory
+}
+
+func TestNICNamer_Names(t *testing.T) {
+	namer := onboardInventory(t).NICNamer("testdata/onboard")
+	for address, want := range map[string]NICName{
+		"0000:19:00.0": {BiosDevName: "em1", Systemd: "eno1", Interfaces: []string{"eno1"}},
+		"19:00.1":      {BiosDevName: "em2", Systemd: "eno2", Interfaces: []string{"eno2"}},
+		"0000:3b:00.0": {BiosDevName: "p1p1", Systemd: "ens1f0", Interfaces: []string{"ens1f0"}},
+		"0000:3b:00.1": {BiosDevName: "p1p2", Systemd: "ens1f1", Interfaces: []string{"ens1f1"}},
+		"0000:5e:00.0": {BiosDevName: "p2p1", Systemd: "ens2", Interfaces: []string{"ens2"}},
+	} {
+		names, err := namer.Names(address)
+		if err != nil {
+			t.Errorf("%s: %v", address, err)
+			continue
+		}
+		if names.BiosDevName != want.BiosDevName || names.Systemd != want.Systemd || !reflect.DeepEqual(names.Interfaces, want.Interfaces) {
+			t.Errorf("%s: got %+v, want %+v", address, names, want)
+		}
+	}
+	// 不是网卡的板载设备(显卡, SATA控制器)
+	for _, address := range []string{"0000:af:00.0", "0000:03:00.0", "0000:00:17.0"} {
+		if names, err := namer.Names(address); err != ErrNoNICName {
+			t.Errorf("%s: got %+v %v, want ErrNoNICName", address, names, err)
+		}
+	}
+
+	// 不读取sysfs时无法知道3b:00.0是多功能设备
+	namer.Root = ""
+	if names, err := namer.Names("0000:3b:00.0"); err != nil || names.Systemd != "ens1" || names.Interfaces != nil {
+		t.Errorf("without sysfs: %+v %v", names, err)
+	}
+}
+
+func TestNICNamer_Mapping(t *testing.T) {
+	namer := onboardInventory(t).NICNamer("testdata/onboard")
+	mapping, err := namer.Mapping()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, names := range mapping {
+		got = append(got, names.Address+" "+names.BiosDevName+" "+names.Systemd)
+	}
+	want := []string{
+		"0000:19:00.0 em1 eno1",
+		"0000:19:00.1 em2 eno2",
+		"0000:3b:00.0 p1p1 ens1f0",
+		"0000:3b:00.1 p1p2 ens1f1",
+		"0000:5e:00.0 p2p1 ens2",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("mapping: got %q, want %q", got, want)
+	}
+
+	// 只有SMBIOS数据时, 插槽只取In Use的Bus Address
+	namer.Root = ""
+	mapping, err = namer.Mapping()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(mapping) != 3 || mapping[2].Address != "0000:3b:00.0" || mapping[2].BiosDevName != "p1p1" {
+		t.Errorf("without sysfs: %+v", mapping)
+	}
+}
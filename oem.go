@@ -0,0 +1,160 @@
+package dmidecode
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// dmidecode -t 11, 按顺序返回所有OEM Strings结构中的字符串
+func (d *DmiDecode) QueryOEMStrings() ([]string, error) {
+	return d.QueryOEMStringsContext(context.Background())
+}
+
+func (d *DmiDecode) QueryOEMStringsContext(ctx context.Context) ([]string, error) {
+	records, err := d.query(ctx, "11")
+	if err != nil {
+		return nil, err
+	}
+	return oemStringsFromRecords(records), nil
+}
+
+func ParseOEMStrings(r io.Reader) ([]string, error) {
+	records, err := ReadRecords(r)
+	if err != nil {
+		return nil, err
+	}
+	return oemStringsFromRecords(records), nil
+}
+
+func (t *Table) QueryOEMStrings() ([]string, error) {
+	return oemStringsFromRecords(t.Records()), nil
+}
+
+// dmidecode -t 12, 按顺序返回所有System Configuration Options结构中的选项
+func (d *DmiDecode) QuerySystemConfigurationOptions() ([]string, error) {
+	return d.QuerySystemConfigurationOptionsContext(context.Background())
+}
+
+func (d *DmiDecode) QuerySystemConfigurationOptionsContext(ctx context.Context) ([]string, error) {
+	records, err := d.query(ctx, "12")
+	if err != nil {
+		return nil, err
+	}
+	return configurationOptionsFromRecords(records), nil
+}
+
+func ParseSystemConfigurationOptions(r io.Reader) ([]string, error) {
+	records, err := ReadRecords(r)
+	if err != nil {
+		return nil, err
+	}
+	return configurationOptionsFromRecords(records), nil
+}
+
+func (t *Table) QuerySystemConfigurationOptions() ([]string, error) {
+	return configurationOptionsFromRecords(t.Records()), nil
+}
+
+// 每个字符串一行, 例如 "String 1: Dell System"
+func oemStringsFromRecords(records []Record) []string {
+	return numberedValues(records, "OEM Strings", "String ")
+}
+
+// 每个选项一行, 例如 "Option 1: NVRAM_CLR: Clear user settable NVRAM areas and set defaults"
+func configurationOptionsFromRecords(records []Record) []string {
+	return numberedValues(records, "System Configuration Options", "Option ")
+}
+
+// 按出现的顺序取记录中 "String 1", "String 2" 等字段的值
+func numberedValues(records []Record, name, prefix string) []string {
+	var result []string
+	for index := range records {
+		if records[index].Name != name {
+			continue
+		}
+		for _, field := range records[index].Fields {
+			if strings.HasPrefix(field.Key, prefix) {
+				result = append(result, field.Value)
+			}
+		}
+	}
+	return result
+}
+
+// 按第一个'='拆分 key=value 形式的字符串, 没有'='时ok为false
+func SplitKeyValue(value string) (key, val string, ok bool) {
+	index := strings.Index(value, "=")
+	if index <= 0 {
+		return "", "", false
+	}
+	return value[:index], value[index+1:], true
+}
+
+// 把 key=value 形式的字符串转换为map, 其他字符串忽略, 重复的key取最后一个值
+func KeyValues(values []string) map[string]string {
+	result := make(map[string]string)
+	for _, value := range values {
+		if key, val, ok := SplitKeyValue(value); ok {
+			result[key] = val
+		}
+	}
+	return result
+}
+
+const (
+	systemdCredentialPrefix       = "io.systemd.credential:"
+	systemdBinaryCredentialPrefix = "io.systemd.credential.binary:"
+)
+
+// systemd通过OEM Strings传递的credential, 例如 qemu -smbios type=11,value=io.systemd.credential:NAME=VALUE
+type SystemdCredential struct {
+	Name  string
+	Value []byte
+}
+
+// 解析 io.systemd.credential:NAME=VALUE 和 io.systemd.credential.binary:NAME=BASE64,
+// 不是credential的字符串返回nil, nil
+func ParseSystemdCredential(value string) (*SystemdCredential, error) {
+	var text string
+	binary := false
+	switch {
+	case strings.HasPrefix(value, systemdCredentialPrefix):
+		text = value[len(systemdCredentialPrefix):]
+	case strings.HasPrefix(value, systemdBinaryCredentialPrefix):
+		text = value[len(systemdBinaryCredentialPrefix):]
+		binary = true
+	default:
+		return nil, nil
+	}
+	name, val, ok := SplitKeyValue(text)
+	if !ok || strings.Contains(name, "/") || name == "." || name == ".." {
+		return nil, fmt.Errorf("dmidecode: malformed systemd credential %q", value)
+	}
+	result := &SystemdCredential{Name: name, Value: []byte(val)}
+	if binary {
+		decoded, err := base64.StdEncoding.DecodeString(val)
+		if err != nil {
+			return nil, fmt.Errorf("dmidecode: malformed systemd credential %q: %v", name, err)
+		}
+		result.Value = decoded
+	}
+	return result, nil
+}
+
+// 按顺序返回所有systemd credential, 忽略其他字符串
+func SystemdCredentials(values []string) ([]SystemdCredential, error) {
+	var result []SystemdCredential
+	for _, value := range values {
+		credential, err := ParseSystemdCredential(value)
+		if err != nil {
+			return nil, err
+		}
+		if credential != nil {
+			result = append(result, *credential)
+		}
+	}
+	return result, nil
+}
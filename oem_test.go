@@ -0,0 +1,63 @@
+package dmidecode
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseOEMStrings(t *testing.T) {
+	values, err := ParseOEMStrings(strings.NewReader(readTestdata(t, "qemu-oem.txt")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(values) != 5 || values[2] != "io.systemd.stub.kernel-cmdline-extra=console=ttyS0" || values[4] != "cloud-init:ds=nocloud" {
+		t.Errorf("strings: %q", values)
+	}
+	want := map[string]string{
+		"io.systemd.credential:firstboot.locale":                "de_DE.UTF-8",
+		"io.systemd.credential.binary:ssh.authorized_keys.root": "c3NoLWVkMjU1MTkgQUFBQSByb290QGhvc3Q=",
+		"io.systemd.stub.kernel-cmdline-extra":                  "console=ttyS0",
+		"provision.role":                                        "worker",
+		"cloud-init:ds":                                         "nocloud",
+	}
+	if got := KeyValues(values); !reflect.DeepEqual(got, want) {
+		t.Errorf("key values: %q", got)
+	}
+
+	credentials, err := SystemdCredentials(values)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(credentials) != 2 || credentials[0].Name != "firstboot.locale" || string(credentials[0].Value) != "de_DE.UTF-8" ||
+		credentials[1].Name != "ssh.authorized_keys.root" || string(credentials[1].Value) != "ssh-ed25519 AAAA root@host" {
+		t.Errorf("credentials: %+v", credentials)
+	}
+
+	options, err := ParseSystemConfigurationOptions(strings.NewReader(readTestdata(t, "qemu-oem.txt")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"NVRAM_CLR: Clear user settable NVRAM areas and set defaults", "PWRD_EN: Close to enable password"}; !reflect.DeepEqual(options, want) {
+		t.Errorf("options: %q", options)
+	}
+}
+
+func TestParseSystemdCredential(t *testing.T) {
+	if credential, err := ParseSystemdCredential("provision.role=worker"); credential != nil || err != nil {
+		t.Errorf("not a credential: %+v %v", credential, err)
+	}
+	for _, value := range []string{"io.systemd.credential:novalue", "io.systemd.credential:=x", "io.systemd.credential:a/b=x", "io.systemd.credential.binary:key=!!"} {
+		if _, err := ParseSystemdCredential(value); err == nil {
+			t.Errorf("%q: expected error", value)
+		}
+	}
+}
+
+func TestDecodeOEMStrings(t *testing.T) {
+	s := &Structure{Type: 11, Length: 5, Handle: 0x0B00, Data: []byte{11, 5, 0x00, 0x0B, 2}, Strings: []string{"Dell System", "5[0000]"}}
+	records := decodeStructure(s, 0x0207)
+	if values := oemStringsFromRecords(records); !reflect.DeepEqual(values, []string{"Dell System", "5[0000]"}) {
+		t.Errorf("decoded: %+v", records)
+	}
+}
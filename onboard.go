@@ -0,0 +1,83 @@
+package dmidecode
+
+import (
+	"context"
+	"io"
+	"strings"
+)
+
+// dmidecode -t 10 -t 41
+// type 10 的一个结构可以包含多个设备, 每个设备是一个OnboardDevice
+type OnboardDevice struct {
+	Handle  Handle            // DMI结构的句柄
+	DMIType int               // DMI type, dmidecode -q 的输出中为-1
+	Extra   map[string]string // 没有对应字段的key, 列表值用换行连接
+	Raw     map[string]string // 被替换的占位符和不在取值表中的枚举的原始值
+	//Reference Designation: Embedded NIC 1, type 10 中为 Description
+	ReferenceDesignation string `dmi:"Reference Designation|Description,normalize"`
+	//Type: Ethernet
+	Type OnboardDeviceType `dmi:"Type"`
+	//Status: Enabled
+	Status string `dmi:"Status"`
+	//Type Instance: 1, 只有type 41有
+	TypeInstance int `dmi:"Type Instance"`
+	//Bus Address: 0000:19:00.0, 只有type 41有
+	BusAddress string `dmi:"Bus Address"`
+}
+
+func (d *DmiDecode) QueryOnboardDevices() ([]*OnboardDevice, error) {
+	return d.QueryOnboardDevicesContext(context.Background())
+}
+
+func (d *DmiDecode) QueryOnboardDevicesContext(ctx context.Context) ([]*OnboardDevice, error) {
+	records, err := d.query(ctx, "10", "41")
+	if err != nil {
+		return nil, err
+	}
+	return onboardDevicesFromRecords(records), nil
+}
+
+func ParseOnboardDevices(r io.Reader) ([]*OnboardDevice, error) {
+	records, err := ReadRecords(r)
+	if err != nil {
+		return nil, err
+	}
+	return onboardDevicesFromRecords(records), nil
+}
+
+func (t *Table) QueryOnboardDevices() ([]*OnboardDevice, error) {
+	return onboardDevicesFromRecords(t.Records()), nil
+}
+
+// 按出现的顺序返回, type 10 和 type 41 可能描述同一个设备
+func onboardDevicesFromRecords(records []Record) []*OnboardDevice {
+	var result []*OnboardDevice
+	for index := range records {
+		if isOnboardDevice(records[index].Name) {
+			device := new(OnboardDevice)
+			DecodeRecord(&records[index], device)
+			result = append(result, device)
+		}
+	}
+	return result
+}
+
+// On Board Device Information, On Board Device 2 Information, Onboard Device
+func isOnboardDevice(name string) bool {
+	if name == "Onboard Device" {
+		return true
+	}
+	return strings.HasPrefix(name, "On Board Device ") && strings.HasSuffix(name, " Information")
+}
+
+func (o *OnboardDevice) Enabled() bool {
+	return o.Status == "Enabled"
+}
+
+// 在 root/sys/bus/pci/devices 中查找设备, root为空或"/"时读取本机; 没有Bus Address时返回ErrNoBusAddress
+func (o *OnboardDevice) PCIDevice(root string) (*PCIDevice, error) {
+	if o.BusAddress == "" {
+		return nil, ErrNoBusAddress
+	}
+	return ResolvePCIDevice(root, o.BusAddress)
+}
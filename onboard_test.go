@@ -0,0 +1,102 @@
+package dmidecode
+
+import (
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseOnboardDevices(t *testing.T) {
+	devices, err := ParseOnboardDevices(strings.NewReader(readTestdata(t, "onboard/dmidecode.txt")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(devices) != 6 {
+		t.Fatalf("devices: got %d", len(devices))
+	}
+	// type 10 同一个handle下的两个设备
+	if video := devices[0]; video.Handle != 0x0A00 || video.DMIType != 10 || video.Type != OnboardDeviceVideo ||
+		video.ReferenceDesignation != "Embedded Video" || !video.Enabled() || video.BusAddress != "" {
+		t.Errorf("type 10: %+v", video)
+	}
+	if nic := devices[1]; nic.Handle != 0x0A00 || nic.Type != OnboardDeviceEthernet || nic.ReferenceDesignation != "Embedded NIC 1" {
+		t.Errorf("type 10: %+v", nic)
+	}
+	if nic := devices[3]; nic.DMIType != 41 || nic.ReferenceDesignation != "Embedded NIC 2" || nic.TypeInstance != 2 ||
+		nic.BusAddress != "0000:19:00.1" || nic.Extra != nil {
+		t.Errorf("type 41: %+v", nic)
+	}
+	if sata := devices[4]; sata.Type != OnboardDeviceSATAController || sata.Enabled() {
+		t.Errorf("disabled: %+v", sata)
+	}
+}
+
+func TestOnboardDevice_PCIDevice(t *testing.T) {
+	devices, err := ParseOnboardDevices(strings.NewReader(readTestdata(t, "onboard/dmidecode.txt")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	pci, err := devices[2].PCIDevice("testdata/onboard")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pci.Address != "0000:19:00.0" || pci.Path != "testdata/onboard/sys/bus/pci/devices/0000:19:00.0" || !reflect.DeepEqual(pci.Interfaces, []string{"eno1"}) {
+		t.Errorf("pci: %+v", pci)
+	}
+	// 不是网卡
+	if video, err := devices[5].PCIDevice("testdata/onboard"); err != nil || len(video.Interfaces) != 0 {
+		t.Errorf("video: %+v %v", video, err)
+	}
+	if _, err := devices[4].PCIDevice("testdata/onboard"); !os.IsNotExist(err) {
+		t.Errorf("missing device: %v", err)
+	}
+	if _, err := devices[0].PCIDevice("testdata/onboard"); err != ErrNoBusAddress {
+		t.Errorf("type 10: %v", err)
+	}
+
+	// virtio的网卡在下一级目录中, 地址可以省略segment
+	virtio, err := ResolvePCIDevice("testdata/onboard", "5E:00.0")
+	if err != nil || virtio.Address != "0000:5e:00.0" || !reflect.DeepEqual(virtio.Interfaces, []string{"ens2"}) {
+		t.Errorf("virtio: %+v %v", virtio, err)
+	}
+	if _, err := ResolvePCIDevice("testdata/onboard", "0000:19:00"); err == nil {
+		t.Error("malformed: expected error")
+	}
+}
+
+func TestResolvePCIDevice_DefaultRoot(t *testing.T) {
+	// root为空时读取本机的 /sys, 而不是当前目录下的 sys
+	dir, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir("testdata/onboard"); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(dir)
+	_, err = ResolvePCIDevice("", "ffff:ff:1f.7")
+	if pathErr, ok := err.(*os.PathError); !ok || pathErr.Path != "/sys/bus/pci/devices/ffff:ff:1f.7" {
+		t.Errorf("got %v", err)
+	}
+	// 当前目录下有这个设备, 但不应该被找到
+	if device, err := ResolvePCIDevice("", "0000:19:00.0"); err == nil && device.Path != "/sys/bus/pci/devices/0000:19:00.0" {
+		t.Errorf("device: %+v", device)
+	}
+}
+
+func TestDecodeOnboardDevices(t *testing.T) {
+	s := &Structure{Type: 10, Length: 8, Handle: 0x0A00, Data: []byte{10, 8, 0x00, 0x0A, 0x83, 1, 0x05, 2}, Strings: []string{"Embedded Video", "Embedded NIC 1"}}
+	records := decodeStructure(s, 0x0302)
+	devices := onboardDevicesFromRecords(records)
+	if len(devices) != 2 || devices[0].Type != OnboardDeviceVideo || !devices[0].Enabled() ||
+		devices[1].ReferenceDesignation != "Embedded NIC 1" || devices[1].Enabled() || records[1].Name != "On Board Device 2 Information" {
+		t.Errorf("type 10: %+v", records)
+	}
+
+	s = &Structure{Type: 41, Length: 11, Handle: 0x2900, Data: []byte{41, 11, 0x00, 0x29, 1, 0x85, 1, 0x00, 0x00, 0x19, 0x01}, Strings: []string{"Embedded NIC 1"}}
+	devices = onboardDevicesFromRecords(decodeStructure(s, 0x0302))
+	if len(devices) != 1 || devices[0].BusAddress != "0000:19:00.1" || devices[0].TypeInstance != 1 || devices[0].Type != OnboardDeviceEthernet {
+		t.Errorf("type 41: %+v", devices)
+	}
+}
@@ -0,0 +1,90 @@
+package dmidecode
+
+import (
+	"io"
+	"io/ioutil"
+)
+
+// 解析已经保存下来的dmidecode输出, 不需要执行命令, 也不需要root权限
+
+// 从io.Reader读取dmidecode的输出并解析为记录
+func ReadRecords(r io.Reader) ([]Record, error) {
+	content, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRecords(string(content)), nil
+}
+
+func ParseBIOS(r io.Reader) (*BiosInfo, *BiosLanguage, error) {
+	records, err := ReadRecords(r)
+	if err != nil {
+		return nil, nil, err
+	}
+	result, language := biosFromRecords(records)
+	return result, language, nil
+}
+
+func ParseSystem(r io.Reader) (*SystemInfo, error) {
+	records, err := ReadRecords(r)
+	if err != nil {
+		return nil, err
+	}
+	return systemFromRecords(records), nil
+}
+
+func ParseBaseBoard(r io.Reader) (*BaseBoardInfo, error) {
+	records, err := ReadRecords(r)
+	if err != nil {
+		return nil, err
+	}
+	return baseBoardFromRecords(records), nil
+}
+
+func ParseChassis(r io.Reader) (*ChassisInfo, error) {
+	records, err := ReadRecords(r)
+	if err != nil {
+		return nil, err
+	}
+	return chassisFromRecords(records), nil
+}
+
+func ParseProcessor(r io.Reader) (*ProcessorInfo, error) {
+	records, err := ReadRecords(r)
+	if err != nil {
+		return nil, err
+	}
+	return processorFromRecords(records), nil
+}
+
+func ParseMemory(r io.Reader) (*MemoryInfo, error) {
+	records, err := ReadRecords(r)
+	if err != nil {
+		return nil, err
+	}
+	return memoryFromRecords(records), nil
+}
+
+func ParseCache(r io.Reader) ([]*CacheInfo, error) {
+	records, err := ReadRecords(r)
+	if err != nil {
+		return nil, err
+	}
+	return cacheFromRecords(records), nil
+}
+
+func ParseConnector(r io.Reader) ([]*PortConnectorInfo, error) {
+	records, err := ReadRecords(r)
+	if err != nil {
+		return nil, err
+	}
+	return connectorFromRecords(records), nil
+}
+
+func ParseSlot(r io.Reader) ([]*SystemSlotInfo, error) {
+	records, err := ReadRecords(r)
+	if err != nil {
+		return nil, err
+	}
+	return slotFromRecords(records), nil
+}
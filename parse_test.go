@@ -0,0 +1,80 @@
+package dmidecode
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+)
+
+func openTestdata(t *testing.T, name string) *os.File {
+	file, err := os.Open("testdata/" + name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return file
+}
+
+func TestParseBIOS(t *testing.T) {
+	file := openTestdata(t, "thinkpad.txt")
+	defer file.Close()
+	bios, language, err := ParseBIOS(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bios.Vendor != "LENOVO" || bios.ReleaseDate != "03/03/2015" {
+		t.Errorf("bios: %+v", bios)
+	}
+	if language.CurrentlyInstalledLanguage != "en-US" {
+		t.Errorf("language: %+v", language)
+	}
+}
+
+func TestParseSystem(t *testing.T) {
+	file := openTestdata(t, "thinkpad.txt")
+	defer file.Close()
+	info, err := ParseSystem(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.ProductName != "20ASEB3" || info.UUID != "3A5F3C81-51E1-11CB-8F39-A8B3CF5D1A8E" {
+		t.Errorf("system: %+v", info)
+	}
+}
+
+func TestParseMemory(t *testing.T) {
+	file := openTestdata(t, "thinkpad.txt")
+	defer file.Close()
+	info, err := ParseMemory(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.NumberOfDevices != "2" || len(info.MemoryList) != 2 {
+		t.Fatalf("memory: %+v", info)
+	}
+	if info.MemoryList[0].PartNumber != "HMT451S6AFR8A-PB" || info.MemoryList[1].Size != "No Module Installed" {
+		t.Errorf("devices: %+v %+v", info.MemoryList[0], info.MemoryList[1])
+	}
+}
+
+func TestParseConnector(t *testing.T) {
+	info, err := ParseConnector(strings.NewReader(readTestdata(t, "thinkpad.txt")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(info) != 2 || info[1].ExternalConnectorType != "RJ-45" {
+		t.Errorf("connector: %+v", info)
+	}
+}
+
+type errReader struct{}
+
+func (errReader) Read([]byte) (int, error) {
+	return 0, errors.New("read failed")
+}
+
+func TestParseSlot_ReadError(t *testing.T) {
+	if _, err := ParseSlot(errReader{}); err == nil {
+		t.Error("expected read error")
+	}
+}
@@ -0,0 +1,72 @@
+package dmidecode
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// 结构中没有Bus Address, 例如type 10的板载设备
+var ErrNoBusAddress = errors.New("dmidecode: no bus address")
+
+// sysfs中的PCI设备
+type PCIDevice struct {
+	Address    string   // 规范化的总线地址, 例如 0000:19:00.0
+	Path       string   // sysfs中的目录
+	Interfaces []string // 网卡名, 例如 eno1, 不是网卡时为空
+}
+
+// 把 "0000:19:00.0" 或省略segment的 "19:00.0" 转换为sysfs中的写法
+func NormalizePCIAddress(address string) (string, error) {
+	var segment, bus, device, function uint
+	text := strings.TrimSpace(address)
+	if strings.Count(text, ":") == 1 {
+		text = "0000:" + text
+	}
+	n, err := fmt.Sscanf(text, "%x:%x:%x.%x", &segment, &bus, &device, &function)
+	if err != nil || n != 4 || segment > 0xFFFF || bus > 0xFF || device > 0x1F || function > 0x07 {
+		return "", fmt.Errorf("dmidecode: malformed PCI address %q", address)
+	}
+	return fmt.Sprintf("%04x:%02x:%02x.%x", segment, bus, device, function), nil
+}
+
+// 在 root/sys/bus/pci/devices 中查找总线地址对应的设备, root为空或"/"时读取本机.
+// 设备不存在时返回的错误满足os.IsNotExist
+func ResolvePCIDevice(root, address string) (*PCIDevice, error) {
+	normalized, err := NormalizePCIAddress(address)
+	if err != nil {
+		return nil, err
+	}
+	if root == "" {
+		root = "/"
+	}
+	path := filepath.Join(root, "sys/bus/pci/devices", normalized)
+	if _, err := os.Stat(path); err != nil {
+		return nil, err
+	}
+	result := &PCIDevice{Address: normalized, Path: path}
+	result.Interfaces, err = netInterfaces(path)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// 网卡在 <device>/net 下; virtio等设备在 <device>/virtio0/net 下
+func netInterfaces(path string) ([]string, error) {
+	var result []string
+	for _, pattern := range []string{"net/*", "*/net/*"} {
+		matches, err := filepath.Glob(filepath.Join(path, pattern))
+		if err != nil {
+			return nil, err
+		}
+		for _, match := range matches {
+			result = append(result, filepath.Base(match))
+		}
+	}
+	sort.Strings(result)
+	return result, nil
+}
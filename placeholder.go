@@ -0,0 +1,78 @@
+package dmidecode
+
+import "strings"
+
+// `dmi:"Key,normalize"` 字段中的占位符(例如 To Be Filled By O.E.M.)默认替换为空字符串,
+// 原始值保存在结构体的Raw中; 序列号, 厂商, 资产编号另有Optional类型的字段, Valid为false表示没有填写.
+// DmiDecode, Table, DmiID 和 DecodeOptions 的KeepPlaceholders为true时保留原始值.
+// 只用于序列号, 厂商这类自由文本字段, Error Correction Type: None 这类枚举值中的None是有意义的, 不做替换
+
+// 厂商没有填写时的值和常见BIOS的默认值, 小写;
+// OEM, NA这类值可能是真实的厂商或资产编号, 不在其中
+var placeholders = map[string]bool{
+	"not specified":            true,
+	"not provided":             true,
+	"not available":            true,
+	"not defined":              true,
+	"not present":              true,
+	"to be filled by o.e.m.":   true,
+	"to be filled by oem":      true,
+	"default string":           true,
+	"none":                     true,
+	"unknown":                  true,
+	"no module installed":      true,
+	"no dimm":                  true,
+	"0123456789":               true,
+	"123456789":                true,
+	"1234567890":               true,
+	"system manufacturer":      true,
+	"system product name":      true,
+	"system version":           true,
+	"system serial number":     true,
+	"base board serial number": true,
+	"chassis manufacture":      true,
+	"chassis version":          true,
+	"chassis serial number":    true,
+	"asset-1234567890":         true,
+	"no asset information":     true,
+	"no asset tag":             true,
+	"<bad index>":              true,
+}
+
+// 是否为厂商没有填写时的占位符, 空字符串也算
+func IsPlaceholder(value string) bool {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if value == "" || placeholders[value] {
+		return true
+	}
+	// 同一个字符重复, 例如 00000000, FFFFFFFF, ........, xxxxxx
+	if len(value) < 4 || !strings.ContainsAny(value[:1], "0fx.-* ") {
+		return false
+	}
+	return strings.Count(value, value[:1]) == len(value)
+}
+
+// 可能没有填写的字符串, Valid为false时Value为空, Raw保存原始值
+type Optional struct {
+	Value string
+	Raw   string
+	Valid bool
+}
+
+// 空字符串和占位符是无效的; KeepPlaceholders为true时由解码器处理, 只有空字符串是无效的
+func (o *Optional) UnmarshalText(text []byte) error {
+	*o = newOptional(string(text), false)
+	return nil
+}
+
+func newOptional(raw string, keep bool) Optional {
+	result := Optional{Raw: raw, Valid: raw != "" && (keep || !IsPlaceholder(raw))}
+	if result.Valid {
+		result.Value = raw
+	}
+	return result
+}
+
+func (o Optional) String() string {
+	return o.Value
+}
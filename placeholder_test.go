@@ -0,0 +1,154 @@
+package dmidecode
+
+import "testing"
+
+func TestIsPlaceholder(t *testing.T) {
+	tests := map[string]bool{
+		"":                       true,
+		"Not Specified":          true,
+		"To Be Filled By O.E.M.": true,
+		"To be filled by O.E.M.": true,
+		"Default string":         true,
+		" None ":                 true,
+		"Unknown":                true,
+		"OEM":                    false,
+		"NA":                     false,
+		"0123456789":             true,
+		"00000000":               true,
+		"FFFFFFFFFFFF":           true,
+		"........":               true,
+		"NO DIMM":                true,
+		"LENOVO":                 false,
+		"PB01ABCD":               false,
+		"000":                    false,
+		"1000":                   false,
+		"Default string:1":       false,
+	}
+	for value, want := range tests {
+		if got := IsPlaceholder(value); got != want {
+			t.Errorf("%q: got %v, want %v", value, got, want)
+		}
+	}
+}
+
+// 空插槽的内存条, 很多BIOS在序列号和厂商中填写Unknown
+const emptySlot = "Memory Device\n\tSize: No Module Installed\n\tManufacturer: Unknown\n" +
+	"\tSerial Number: Unknown\n\tAsset Tag: None\n\tPart Number: OEM\n"
+
+func TestOptional(t *testing.T) {
+	var serial Optional
+	serial.UnmarshalText([]byte("To Be Filled By O.E.M."))
+	if serial.Valid || serial.Value != "" || serial.Raw != "To Be Filled By O.E.M." {
+		t.Errorf("serial: %+v", serial)
+	}
+	serial.UnmarshalText([]byte("PB01ABCD"))
+	if !serial.Valid || serial.String() != "PB01ABCD" {
+		t.Errorf("serial: %+v", serial)
+	}
+
+	records := ParseRecords(emptySlot)
+	var device MemoryDevice
+	if err := DecodeRecord(&records[0], &device); err != nil {
+		t.Fatal(err)
+	}
+	if device.SerialNumber != "" || device.SerialNumberOptional.Valid || device.SerialNumberOptional.Raw != "Unknown" ||
+		device.ManufacturerOptional.Valid || device.AssetTagOptional.Valid || device.PartNumber != "OEM" {
+		t.Errorf("empty slot: %+v", device)
+	}
+	device = MemoryDevice{}
+	if err := (DecodeOptions{KeepPlaceholders: true}).DecodeRecord(&records[0], &device); err != nil {
+		t.Fatal(err)
+	}
+	if device.SerialNumber != "Unknown" || !device.SerialNumberOptional.Valid || device.SerialNumberOptional.Value != "Unknown" {
+		t.Errorf("keep: %+v", device)
+	}
+}
+
+func TestNormalizePlaceholders(t *testing.T) {
+	records := ParseRecords(readTestdata(t, "thinkpad.txt"))
+	board := baseBoardFromRecords(records)
+	if board.Version != "" || board.Raw["Version"] != "Not Defined" || board.AssertTag != "" || board.Manufacturer != "LENOVO" ||
+		board.AssetTagOptional.Valid || board.AssetTagOptional.Raw != "Not Available" || board.ManufacturerOptional.Value != "LENOVO" {
+		t.Errorf("baseboard: %+v", board)
+	}
+	// 枚举值中的None不是占位符
+	device := memoryFromRecords(records).MemoryList[0]
+	if device.Set != "None" {
+		t.Errorf("set: %q", device.Set)
+	}
+
+	var decoded BaseBoardInfo
+	if err := (DecodeOptions{KeepPlaceholders: true}).DecodeRecords(records, 2, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.Version != "Not Defined" || decoded.Raw != nil {
+		t.Errorf("baseboard: %+v", decoded)
+	}
+}
+
+func TestKeepPlaceholders(t *testing.T) {
+	d := replayInstance(t)
+	d.KeepPlaceholders = true
+	board, err := d.QueryBaseBoard()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if board.Version != "Not Defined" || board.Raw != nil {
+		t.Errorf("baseboard: %+v", board)
+	}
+	// 其他实例不受影响
+	board, err = replayInstance(t).QueryBaseBoard()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if board.Version != "" || board.Raw["Version"] != "Not Defined" {
+		t.Errorf("baseboard: %+v", board)
+	}
+	board, err = (&DmiID{Root: "testdata/dmiid", KeepPlaceholders: true}).QueryBaseBoard()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if board.Version != "Not Defined" || board.Raw != nil {
+		t.Errorf("baseboard: %+v", board)
+	}
+}
+
+// 不是root时降级到 /sys/class/dmi/id, 同样按实例的设置处理占位符
+func TestKeepPlaceholders_Fallback(t *testing.T) {
+	defer func(saved string) { fallbackRoot = saved }(fallbackRoot)
+	fallbackRoot = "testdata/dmiid"
+
+	d := &DmiDecode{KeepPlaceholders: true}
+	board, err := d.QueryBaseBoard()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if board.Version != "Not Defined" || board.Raw != nil {
+		t.Errorf("keep: %+v", board)
+	}
+	board, err = new(DmiDecode).QueryBaseBoard()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if board.Version != "" || board.Raw["Version"] != "Not Defined" {
+		t.Errorf("normalize: %+v", board)
+	}
+}
+
+func TestDmiID_Placeholders(t *testing.T) {
+	board, err := (&DmiID{Root: "testdata/dmiid"}).QueryBaseBoard()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if board.Version != "" || board.Raw["Version"] != "Not Defined" || !board.ManufacturerOptional.Valid ||
+		board.AssetTagOptional.Valid || board.AssetTagOptional.Raw != "Not Available" {
+		t.Errorf("baseboard: %+v", board)
+	}
+	board, err = (&DmiID{Root: "testdata/dmiid", KeepPlaceholders: true}).QueryBaseBoard()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if board.Version != "Not Defined" || board.Raw != nil {
+		t.Errorf("baseboard: %+v", board)
+	}
+}
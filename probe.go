@@ -0,0 +1,228 @@
+package dmidecode
+
+import (
+	"context"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// 探针和风扇的测量值, 电压为V, 温度为摄氏度, 电流为A, 精度为百分比, 转速为rpm.
+// dmidecode输出Unknown时Valid为false
+type ProbeValue struct {
+	Value float64
+	Valid bool
+}
+
+// 例如 "12.000 V", "1.0 mV", "25.0 deg C", "0.50%", "3200 rpm", mV和mA换算为V和A
+func (p *ProbeValue) UnmarshalText(text []byte) error {
+	*p = ProbeValue{}
+	fields := strings.Fields(strings.Replace(string(text), "%", "", 1))
+	if len(fields) == 0 {
+		return nil
+	}
+	value, err := strconv.ParseFloat(fields[0], 64)
+	if err != nil {
+		return nil
+	}
+	if len(fields) > 1 && (fields[1] == "mV" || fields[1] == "mA") {
+		value /= 1000
+	}
+	*p = ProbeValue{Value: value, Valid: true}
+	return nil
+}
+
+func (p ProbeValue) String() string {
+	if !p.Valid {
+		return "Unknown"
+	}
+	return strconv.FormatFloat(p.Value, 'f', -1, 64)
+}
+
+// Voltage Probe(type 26), Temperature Probe(type 28), Electrical Current Probe(type 29),
+// 三种探针的字段相同, 单位见ProbeValue
+type Probe struct {
+	Handle  Handle            // DMI结构的句柄
+	DMIType int               // DMI type, dmidecode -q 的输出中为-1
+	Extra   map[string]string // 没有对应字段的key, 列表值用换行连接
+	Raw     map[string]string // 被替换的占位符和不在取值表中的枚举的原始值
+	//Description: LM78A
+	Description string `dmi:"Description,normalize"`
+	//Location: Motherboard
+	Location string `dmi:"Location"`
+	//Status: OK
+	Status string `dmi:"Status"`
+	//Maximum Value: 12.000 V
+	Maximum ProbeValue `dmi:"Maximum Value"`
+	//Minimum Value: Unknown
+	Minimum ProbeValue `dmi:"Minimum Value"`
+	//Resolution: 1.0 mV
+	Resolution ProbeValue `dmi:"Resolution"`
+	//Tolerance: 0.500 V
+	Tolerance ProbeValue `dmi:"Tolerance"`
+	//Accuracy: 0.50%
+	Accuracy ProbeValue `dmi:"Accuracy"`
+	//OEM-specific Information: 0x00000000
+	OEMInformation string `dmi:"OEM-specific Information"`
+	//Nominal Value: 5.000 V
+	Nominal ProbeValue `dmi:"Nominal Value"`
+}
+
+// dmidecode -t 27
+type CoolingDevice struct {
+	Handle  Handle            // DMI结构的句柄
+	DMIType int               // DMI type, dmidecode -q 的输出中为-1
+	Extra   map[string]string // 没有对应字段的key, 列表值用换行连接
+	Raw     map[string]string // 被替换的占位符和不在取值表中的枚举的原始值
+	//Temperature Probe Handle: 0x0036
+	TemperatureProbeHandle string `dmi:"Temperature Probe Handle"`
+	//Type: Chip Fan
+	Type string `dmi:"Type"`
+	//Status: OK
+	Status string `dmi:"Status"`
+	//Cooling Unit Group: 1
+	CoolingUnitGroup int `dmi:"Cooling Unit Group"`
+	//OEM-specific Information: 0x00000000
+	OEMInformation string `dmi:"OEM-specific Information"`
+	//Nominal Speed: 3200 rpm 或 Unknown Or Non-rotating
+	NominalSpeed ProbeValue `dmi:"Nominal Speed"`
+	//Description: Cooling Dev 1
+	Description string `dmi:"Description,normalize"`
+
+	probe *Probe
+}
+
+// dmidecode -t 26, 单位为V
+func (d *DmiDecode) QueryVoltageProbes() ([]*Probe, error) {
+	return d.queryProbes(context.Background(), "26", "Voltage Probe")
+}
+
+func (d *DmiDecode) QueryVoltageProbesContext(ctx context.Context) ([]*Probe, error) {
+	return d.queryProbes(ctx, "26", "Voltage Probe")
+}
+
+// dmidecode -t 28, 单位为摄氏度
+func (d *DmiDecode) QueryTemperatureProbes() ([]*Probe, error) {
+	return d.queryProbes(context.Background(), "28", "Temperature Probe")
+}
+
+func (d *DmiDecode) QueryTemperatureProbesContext(ctx context.Context) ([]*Probe, error) {
+	return d.queryProbes(ctx, "28", "Temperature Probe")
+}
+
+// dmidecode -t 29, 单位为A
+func (d *DmiDecode) QueryCurrentProbes() ([]*Probe, error) {
+	return d.queryProbes(context.Background(), "29", "Electrical Current Probe")
+}
+
+func (d *DmiDecode) QueryCurrentProbesContext(ctx context.Context) ([]*Probe, error) {
+	return d.queryProbes(ctx, "29", "Electrical Current Probe")
+}
+
+func (d *DmiDecode) queryProbes(ctx context.Context, keyword, name string) ([]*Probe, error) {
+	records, err := d.query(ctx, keyword)
+	if err != nil {
+		return nil, err
+	}
+	return probesFromRecords(records, name), nil
+}
+
+// 同时查询温度探针, 用于CoolingDevice.TemperatureProbe()
+func (d *DmiDecode) QueryCoolingDevices() ([]*CoolingDevice, error) {
+	return d.QueryCoolingDevicesContext(context.Background())
+}
+
+func (d *DmiDecode) QueryCoolingDevicesContext(ctx context.Context) ([]*CoolingDevice, error) {
+	records, err := d.query(ctx, "27", "28")
+	if err != nil {
+		return nil, err
+	}
+	return coolingDevicesFromRecords(records), nil
+}
+
+func ParseVoltageProbes(r io.Reader) ([]*Probe, error) {
+	return parseProbes(r, "Voltage Probe")
+}
+
+func ParseTemperatureProbes(r io.Reader) ([]*Probe, error) {
+	return parseProbes(r, "Temperature Probe")
+}
+
+func ParseCurrentProbes(r io.Reader) ([]*Probe, error) {
+	return parseProbes(r, "Electrical Current Probe")
+}
+
+func parseProbes(r io.Reader, name string) ([]*Probe, error) {
+	records, err := ReadRecords(r)
+	if err != nil {
+		return nil, err
+	}
+	return probesFromRecords(records, name), nil
+}
+
+func ParseCoolingDevices(r io.Reader) ([]*CoolingDevice, error) {
+	records, err := ReadRecords(r)
+	if err != nil {
+		return nil, err
+	}
+	return coolingDevicesFromRecords(records), nil
+}
+
+func (t *Table) QueryVoltageProbes() ([]*Probe, error) {
+	return probesFromRecords(t.Records(), "Voltage Probe"), nil
+}
+
+func (t *Table) QueryTemperatureProbes() ([]*Probe, error) {
+	return probesFromRecords(t.Records(), "Temperature Probe"), nil
+}
+
+func (t *Table) QueryCurrentProbes() ([]*Probe, error) {
+	return probesFromRecords(t.Records(), "Electrical Current Probe"), nil
+}
+
+func (t *Table) QueryCoolingDevices() ([]*CoolingDevice, error) {
+	return coolingDevicesFromRecords(t.Records()), nil
+}
+
+func probesFromRecords(records []Record, name string) []*Probe {
+	var result []*Probe
+	for index := range records {
+		if records[index].Name == name {
+			probe := new(Probe)
+			DecodeRecord(&records[index], probe)
+			result = append(result, probe)
+		}
+	}
+	return result
+}
+
+func coolingDevicesFromRecords(records []Record) []*CoolingDevice {
+	return linkCoolingDevices(records, probesFromRecords(records, "Temperature Probe"))
+}
+
+// TemperatureProbe()返回temperatureProbes中的元素
+func linkCoolingDevices(records []Record, temperatureProbes []*Probe) []*CoolingDevice {
+	probes := make(map[Handle]*Probe)
+	for _, probe := range temperatureProbes {
+		if probe.DMIType >= 0 {
+			probes[probe.Handle] = probe
+		}
+	}
+	var result []*CoolingDevice
+	for index := range records {
+		if records[index].Name == "Cooling Device" {
+			device := new(CoolingDevice)
+			DecodeRecord(&records[index], device)
+			if handle, ok := parseHandle(device.TemperatureProbeHandle); ok {
+				device.probe = probes[handle]
+			}
+			result = append(result, device)
+		}
+	}
+	return result
+}
+
+// 风扇对应的温度探针, 没有Temperature Probe Handle或没有找到时返回nil
+func (c *CoolingDevice) TemperatureProbe() *Probe {
+	return c.probe
+}
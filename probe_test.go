@@ -0,0 +1,86 @@
+package dmidecode
+
+import (
+	"encoding/binary"
+	"strings"
+	"testing"
+)
+
+func TestParseProbes(t *testing.T) {
+	inventory, err := ParseAll(strings.NewReader(readTestdata(t, "probes.txt")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(inventory.VoltageProbes) != 1 || len(inventory.TemperatureProbes) != 1 || len(inventory.CurrentProbes) != 1 {
+		t.Fatalf("probes: %+v", inventory)
+	}
+	voltage := inventory.VoltageProbes[0]
+	if voltage.Description != "CPU Core" || voltage.Maximum != (ProbeValue{1.5, true}) || voltage.Minimum.Valid ||
+		voltage.Resolution != (ProbeValue{0.001, true}) || voltage.Accuracy != (ProbeValue{0.5, true}) ||
+		voltage.Nominal != (ProbeValue{1.2, true}) || voltage.Extra != nil {
+		t.Errorf("voltage: %+v", voltage)
+	}
+	temperature := inventory.TemperatureProbes[0]
+	if temperature.Maximum.Value != 95 || temperature.Minimum != (ProbeValue{-10, true}) || temperature.Tolerance.Valid || temperature.Accuracy.Valid {
+		t.Errorf("temperature: %+v", temperature)
+	}
+	current := inventory.CurrentProbes[0]
+	if current.Status != "Non-critical" || current.Resolution != (ProbeValue{0.1, true}) || current.Nominal.Valid || current.Nominal.String() != "Unknown" {
+		t.Errorf("current: %+v", current)
+	}
+	if len(inventory.Dangling) != 0 || len(inventory.UnknownKeys()) != 0 {
+		t.Errorf("dangling: %+v, unknown: %q", inventory.Dangling, inventory.UnknownKeys())
+	}
+}
+
+func TestParseCoolingDevices(t *testing.T) {
+	devices, err := ParseCoolingDevices(strings.NewReader(readTestdata(t, "probes.txt")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(devices) != 2 {
+		t.Fatalf("devices: got %d", len(devices))
+	}
+	fan := devices[0]
+	if fan.Type != "Chip Fan" || fan.CoolingUnitGroup != 1 || fan.NominalSpeed != (ProbeValue{3200, true}) || fan.Description != "CPU Fan" {
+		t.Errorf("fan: %+v", fan)
+	}
+	if probe := fan.TemperatureProbe(); probe == nil || probe.Handle != 0x0033 || probe.Nominal.Value != 45 {
+		t.Errorf("temperature probe: %+v", probe)
+	}
+	pipe := devices[1]
+	if pipe.TemperatureProbe() != nil || pipe.NominalSpeed.Valid || pipe.Description != "" {
+		t.Errorf("heat pipe: %+v", pipe)
+	}
+}
+
+func TestDecodeProbe(t *testing.T) {
+	data := make([]byte, 22)
+	data[0], data[1] = 28, 22
+	data[0x04] = 1
+	data[0x05] = 0x03<<5 | 0x03
+	binary.LittleEndian.PutUint16(data[0x06:], 950)
+	binary.LittleEndian.PutUint16(data[0x08:], uint16(0xFFFF-99)) // -10.0 deg C
+	binary.LittleEndian.PutUint16(data[0x0A:], 500)
+	binary.LittleEndian.PutUint16(data[0x0C:], 0x8000)
+	binary.LittleEndian.PutUint16(data[0x0E:], 0x8000)
+	binary.LittleEndian.PutUint16(data[0x14:], 450)
+	probe := &Structure{Type: 28, Length: 22, Handle: 0x0033, Data: data, Strings: []string{"CPU Thermal Probe"}}
+
+	fan := &Structure{Type: 27, Length: 15, Handle: 0x0034, Data: []byte{27, 15, 0x34, 0x00, 0x33, 0x00, 0x03<<5 | 0x05, 1, 0, 0, 0, 0, 0x80, 0x0C, 1},
+		Strings: []string{"CPU Fan"}}
+
+	records := append(decodeStructure(probe, 0x0208), decodeStructure(fan, 0x0208)...)
+	if field := records[0].Field("Minimum Value"); field == nil || field.Value != "-10.0 deg C" {
+		t.Errorf("minimum: %+v", records[0])
+	}
+	devices := coolingDevicesFromRecords(records)
+	if len(devices) != 1 || devices[0].Type != "Chip Fan" || devices[0].NominalSpeed.Value != 3200 {
+		t.Fatalf("devices: %+v", devices)
+	}
+	temperature := devices[0].TemperatureProbe()
+	if temperature == nil || temperature.Location != "Processor" || temperature.Status != "OK" || temperature.Maximum.Value != 95 ||
+		temperature.Resolution.Value != 0.5 || temperature.Tolerance.Valid || temperature.Nominal.Value != 45 {
+		t.Errorf("temperature: %+v", temperature)
+	}
+}
@@ -0,0 +1,62 @@
+package dmidecode
+
+import (
+	"context"
+	"io"
+	"strings"
+)
+
+// 返回所有处理器插槽, 按handle顺序, 包括没有安装处理器的插槽
+func (d *DmiDecode) QueryProcessors() ([]*ProcessorInfo, error) {
+	return d.QueryProcessorsContext(context.Background())
+}
+
+// 同时查询cache, 用于ProcessorInfo.Caches()
+func (d *DmiDecode) QueryProcessorsContext(ctx context.Context) ([]*ProcessorInfo, error) {
+	records, err := d.query(ctx, "processor", "cache")
+	if err != nil {
+		return nil, err
+	}
+	return processorsFromRecords(records), nil
+}
+
+func ParseProcessors(r io.Reader) ([]*ProcessorInfo, error) {
+	records, err := ReadRecords(r)
+	if err != nil {
+		return nil, err
+	}
+	return processorsFromRecords(records), nil
+}
+
+func (t *Table) QueryProcessors() ([]*ProcessorInfo, error) {
+	return processorsFromRecords(t.Records()), nil
+}
+
+// 插槽上是否安装了处理器, Status: Populated, Enabled
+func (p *ProcessorInfo) Populated() bool {
+	return strings.HasPrefix(p.Status, "Populated")
+}
+
+// 处理器汇总, 核数和线程数只统计已安装的插槽
+type ProcessorSummary struct {
+	Sockets          int // 插槽总数
+	PopulatedSockets int // 已安装处理器的插槽数
+	Cores            int // Core Count 之和
+	EnabledCores     int // Core Enabled 之和
+	Threads          int // Thread Count 之和
+}
+
+func SummarizeProcessors(processors []*ProcessorInfo) ProcessorSummary {
+	var result ProcessorSummary
+	for _, processor := range processors {
+		result.Sockets++
+		if !processor.Populated() {
+			continue
+		}
+		result.PopulatedSockets++
+		result.Cores += processor.Cores
+		result.EnabledCores += processor.EnabledCores
+		result.Threads += processor.Threads
+	}
+	return result
+}
@@ -0,0 +1,36 @@
+package dmidecode
+
+import "testing"
+
+func TestParseProcessors(t *testing.T) {
+	processors, err := ParseProcessors(openTestdata(t, "server-4socket.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(processors) != 4 {
+		t.Fatalf("processors: got %d, want 4", len(processors))
+	}
+	for index, want := range []string{"CPU1", "CPU2", "CPU3", "CPU4"} {
+		if processors[index].SocketDesignation != want {
+			t.Errorf("processor %d: got %q, want %q", index, processors[index].SocketDesignation, want)
+		}
+	}
+	if !processors[1].Populated() || processors[2].Populated() {
+		t.Errorf("populated: %q %q", processors[1].Status, processors[2].Status)
+	}
+
+	summary := SummarizeProcessors(processors)
+	want := ProcessorSummary{Sockets: 4, PopulatedSockets: 2, Cores: 32, EnabledCores: 30, Threads: 64}
+	if summary != want {
+		t.Errorf("summary: got %+v, want %+v", summary, want)
+	}
+
+	// 兼容原来的接口, 返回最后一个
+	last, err := ParseProcessor(openTestdata(t, "server-4socket.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if last.SocketDesignation != "CPU4" {
+		t.Errorf("last: %+v", last)
+	}
+}
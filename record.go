@@ -0,0 +1,163 @@
+package dmidecode
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// DMI结构的句柄, 例如: Handle 0x0005
+type Handle uint16
+
+func (h Handle) String() string {
+	return fmt.Sprintf("0x%04X", uint16(h))
+}
+
+// 解析 "0x0005" 形式的句柄, Not Provided 等返回false
+func parseHandle(value string) (Handle, bool) {
+	if !strings.HasPrefix(value, "0x") {
+		return 0, false
+	}
+	handle, err := strconv.ParseUint(value[2:], 16, 16)
+	if err != nil {
+		return 0, false
+	}
+	return Handle(handle), true
+}
+
+// 记录中的一个字段, 例如 Characteristics 这种多行的值保存在List中
+type Field struct {
+	Key   string
+	Value string
+	List  []string
+}
+
+// dmidecode输出中的一个结构, 对应 "Handle 0x0000, DMI type 0, 24 bytes" 开头的一段
+type Record struct {
+	Handle Handle
+	Type   int // DMI type, dmidecode -q 的输出中没有handle行, 此时为-1
+	Size   int
+	Name   string
+	Fields []Field
+
+	keepPlaceholders bool       // 来自KeepPlaceholders为true的DmiDecode或Table, 解码时保留占位符
+	version          int        // SMBIOS版本, 例如0x0207, 来自 "SMBIOS 2.7 present." 或入口点, 未知时为0
+	structure        *Structure // 解码出这个记录的结构, 只有Table.Records()的结果有, 用于dmidecode不输出的字段
+}
+
+// 查找字段, 不存在返回nil
+func (r *Record) Field(key string) *Field {
+	for index := range r.Fields {
+		if r.Fields[index].Key == key {
+			return &r.Fields[index]
+		}
+	}
+	return nil
+}
+
+// 字段的值, 不存在返回空字符串
+func (r *Record) Value(key string) string {
+	if field := r.Field(key); field != nil {
+		return field.Value
+	}
+	return ""
+}
+
+// 字段的多行值, 不存在返回nil
+func (r *Record) List(key string) []string {
+	if field := r.Field(key); field != nil {
+		return field.List
+	}
+	return nil
+}
+
+// 把没有对应字段的key保存到extra中, 列表值用换行连接
+func addExtra(extra map[string]string, field Field) map[string]string {
+	if extra == nil {
+		extra = make(map[string]string)
+	}
+	value := field.Value
+	if len(field.List) > 0 {
+		value = strings.Join(field.List, "\n")
+	}
+	extra[field.Key] = value
+	return extra
+}
+
+// 解析dmidecode的文本输出, 按出现顺序返回所有记录
+func ParseRecords(text string) []Record {
+	var result []Record
+	var current *Record
+	// 已经读到handle行, 下一行是记录名
+	var wantName bool
+	var version int
+
+	flush := func() {
+		// 没有handle也没有字段的是输出头部的说明文字, 丢弃
+		if current != nil && (current.Type >= 0 || len(current.Fields) > 0) {
+			result = append(result, *current)
+		}
+		current = nil
+		wantName = false
+	}
+
+	text = strings.Replace(text, "\r\n", "\n", -1)
+	for _, line := range strings.Split(text, "\n") {
+		switch {
+		case strings.TrimSpace(line) == "":
+			flush()
+		case strings.HasPrefix(line, "SMBIOS ") && strings.HasSuffix(line, " present."):
+			var major, minor int
+			if n, _ := fmt.Sscanf(line, "SMBIOS %d.%d", &major, &minor); n == 2 {
+				version = major<<8 | minor
+			}
+		case strings.HasPrefix(line, "Handle "):
+			flush()
+			current = &Record{Type: -1}
+			var handle uint16
+			n, _ := fmt.Sscanf(line, "Handle 0x%x, DMI type %d, %d bytes", &handle, &current.Type, &current.Size)
+			if n > 0 {
+				current.Handle = Handle(handle)
+			}
+			wantName = true
+		case strings.HasPrefix(line, "\t\t"):
+			if current != nil && len(current.Fields) > 0 {
+				field := &current.Fields[len(current.Fields)-1]
+				field.List = append(field.List, strings.TrimSpace(line))
+			}
+		case strings.HasPrefix(line, "\t"):
+			if current == nil {
+				continue
+			}
+			wantName = false
+			var field Field
+			// 只按第一个冒号切分, 值中可能包含冒号
+			if index := strings.Index(line, ":"); index >= 0 {
+				field.Key = strings.TrimSpace(line[:index])
+				field.Value = strings.TrimSpace(line[index+1:])
+			} else {
+				field.Key = strings.TrimSpace(line)
+			}
+			current.Fields = append(current.Fields, field)
+		default:
+			if wantName {
+				current.Name = strings.TrimSpace(line)
+				wantName = false
+				continue
+			}
+			// dmidecode -q 没有handle行, 记录名直接出现在行首;
+			// 同一个handle下也可能有多个记录名(例如 On Board Device 1 Information)
+			next := &Record{Type: -1, Name: strings.TrimSpace(line)}
+			if current != nil && (current.Type >= 0 || len(current.Fields) > 0) {
+				next.Handle, next.Type, next.Size = current.Handle, current.Type, current.Size
+				flush()
+			}
+			current = next
+		}
+	}
+	flush()
+	for index := range result {
+		result[index].version = version
+	}
+	return result
+}
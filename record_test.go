@@ -0,0 +1,150 @@
+package dmidecode
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func readTestdata(t *testing.T, name string) string {
+	content, err := ioutil.ReadFile("testdata/" + name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(content)
+}
+
+func TestParseRecords(t *testing.T) {
+	records := ParseRecords(readTestdata(t, "thinkpad.txt"))
+	if len(records) != 17 {
+		t.Fatalf("records: got %d, want 17", len(records))
+	}
+	bios := records[0]
+	if bios.Handle != 0 || bios.Type != 0 || bios.Size != 24 || bios.Name != "BIOS Information" {
+		t.Errorf("bios header: %+v", bios)
+	}
+	if bios.Value("Vendor") != "LENOVO" {
+		t.Errorf("Vendor: got %q", bios.Value("Vendor"))
+	}
+	if list := bios.List("Characteristics"); len(list) != 10 || list[0] != "PCI is supported" {
+		t.Errorf("Characteristics: got %q", list)
+	}
+	slot := records[13]
+	if slot.Handle.String() != "0x000D" || slot.Value("Bus Address") != "0000:00:1c.0" {
+		t.Errorf("slot: %+v", slot)
+	}
+	last := records[len(records)-1]
+	if last.Name != "End Of Table" || last.Type != 127 || len(last.Fields) != 0 {
+		t.Errorf("end of table: %+v", last)
+	}
+}
+
+func TestParseRecords_Quiet(t *testing.T) {
+	text := "System Information\n\tManufacturer: QEMU\n\tProduct Name: Standard PC\n\n" +
+		"On Board Device 1 Information\n\tType: Video\n" +
+		"On Board Device 2 Information\n\tType: Ethernet\n"
+	records := ParseRecords(text)
+	if len(records) != 3 {
+		t.Fatalf("records: got %d, want 3", len(records))
+	}
+	if records[0].Type != -1 || records[0].Value("Product Name") != "Standard PC" {
+		t.Errorf("system: %+v", records[0])
+	}
+	if records[2].Name != "On Board Device 2 Information" || records[2].Value("Type") != "Ethernet" {
+		t.Errorf("onboard: %+v", records[2])
+	}
+}
+
+func TestBuildFromRecords(t *testing.T) {
+	records := ParseRecords(readTestdata(t, "thinkpad.txt"))
+	bios, language := biosFromRecords(records)
+	if bios.BIOSRevision != "1.76" || len(bios.Characteristics) != 10 {
+		t.Errorf("bios: %+v", bios)
+	}
+	if language.InstallableLanguagesNumber != 3 || language.InstallableLanguages[2] != "ja-JP" {
+		t.Errorf("language: %+v", language)
+	}
+	chassis := chassisFromRecords(records)
+	if chassis.OEMInformation != "0x00000000" || chassis.SecurityStatus != "Unknown" {
+		t.Errorf("chassis: %+v", chassis)
+	}
+	processor := processorFromRecords(records)
+	if processor.L3CacheHandle != "0x0008" || len(processor.Characteristics) != 6 || len(processor.Flags) != 4 {
+		t.Errorf("processor: %+v", processor)
+	}
+	memory := memoryFromRecords(records)
+	if memory.MaximumCapacity != "16 GB" || len(memory.MemoryList) != 2 {
+		t.Errorf("memory: %+v", memory)
+	}
+	if caches := cacheFromRecords(records); len(caches) != 3 {
+		t.Errorf("caches: got %d, want 3", len(caches))
+	}
+	if slots := slotFromRecords(records); len(slots) != 1 || slots[0].ID != "1" {
+		t.Errorf("slots: %+v", slots)
+	}
+}
+
+// testdata/colons 中是按真实机型的输出格式手工编写的样本, 值里包含冒号, 每个字段都必须原样保留
+func TestParseRecords_Colons(t *testing.T) {
+	files, err := filepath.Glob("testdata/colons/*.txt")
+	if err != nil || len(files) == 0 {
+		t.Fatalf("corpus: %v %v", files, err)
+	}
+	for _, file := range files {
+		text := readTestdata(t, strings.TrimPrefix(file, "testdata/"))
+		var want []string
+		for _, line := range strings.Split(text, "\n") {
+			if strings.HasPrefix(line, "\t") && !strings.HasPrefix(line, "\t\t") {
+				want = append(want, strings.TrimSpace(line))
+			}
+		}
+		var got []string
+		for _, record := range ParseRecords(text) {
+			for _, field := range record.Fields {
+				if field.Value == "" {
+					got = append(got, field.Key+":")
+				} else {
+					got = append(got, field.Key+": "+field.Value)
+				}
+			}
+		}
+		if strings.Join(got, "\n") != strings.Join(want, "\n") {
+			t.Errorf("%s: fields lost\ngot:\n%s\nwant:\n%s", file, strings.Join(got, "\n"), strings.Join(want, "\n"))
+		}
+	}
+}
+
+func TestBuildFromRecords_Colons(t *testing.T) {
+	dell := ParseRecords(readTestdata(t, "colons/synthetic-dell.txt"))
+	bios, _ := biosFromRecords(dell)
+	if bios.Version != "2.10.2 (build 2021-03-04T09:15:30)" {
+		t.Errorf("bios version: %q", bios.Version)
+	}
+	if chassis := chassisFromRecords(dell); chassis.AssertTag != "rack:R12 unit:U07" {
+		t.Errorf("chassis asset tag: %q", chassis.AssertTag)
+	}
+	slots := slotFromRecords(dell)
+	if len(slots) != 2 || slots[0].BusAddress != "0000:3b:00.0" || slots[1].BusAddress != "0000:5e:00.0" {
+		t.Errorf("slots: %+v", slots)
+	}
+
+	supermicro := ParseRecords(readTestdata(t, "colons/synthetic-supermicro.txt"))
+	if system := systemFromRecords(supermicro); system.Version != "0123456789:rev B" || system.SKUNumber != "Default string:1" {
+		t.Errorf("system: %+v", system)
+	}
+	board := baseBoardFromRecords(supermicro)
+	if board.AssertTag != "mac:ac:1f:6b:7c:8d:9e" || board.LocationInChassis != "slot:1" || board.Version != "1.10:A" {
+		t.Errorf("baseboard: %+v", board)
+	}
+	if chassis := chassisFromRecords(supermicro); chassis.AssertTag != "ticket:INV-2019:0042" {
+		t.Errorf("chassis asset tag: %q", chassis.AssertTag)
+	}
+	connectors := connectorFromRecords(supermicro)
+	if len(connectors) != 1 || connectors[0].InternalReferenceDesignator != "JUSB1:USB3.0" {
+		t.Errorf("connectors: %+v", connectors)
+	}
+	if slots := slotFromRecords(supermicro); len(slots) != 1 || slots[0].BusAddress != "0000:18:00.0" {
+		t.Errorf("slots: %+v", slots)
+	}
+}
@@ -0,0 +1,76 @@
+package dmidecode
+
+import "strings"
+
+// 结构之间的引用, 例如Memory Device的Array Handle
+type Reference struct {
+	From  Handle // 引用方的句柄
+	Name  string // 引用方的记录名, 例如 Memory Device
+	Field string // 引用字段, 例如 Array Handle
+	To    Handle // 被引用的句柄
+}
+
+// 返回所有指向不存在的结构的引用, 需要传入完整的dmidecode输出,
+// 只有 -t processor 的输出中cache handle都会被当作悬空引用;
+// dmidecode -q 的输出没有handle, 返回nil
+func DanglingReferences(records []Record) []Reference {
+	handles := make(map[Handle]bool)
+	for _, record := range records {
+		if record.Type >= 0 {
+			handles[record.Handle] = true
+		}
+	}
+	if len(handles) == 0 {
+		return nil
+	}
+	var result []Reference
+	for _, record := range records {
+		if record.Type < 0 {
+			continue
+		}
+		for _, field := range record.Fields {
+			if !strings.HasSuffix(field.Key, " Handle") {
+				continue
+			}
+			// Not Provided / No Error 等不是引用
+			to, ok := parseHandle(field.Value)
+			if ok && !handles[to] {
+				result = append(result, Reference{From: record.Handle, Name: record.Name, Field: field.Key, To: to})
+			}
+		}
+	}
+	return result
+}
+
+// 处理器的L1/L2/L3缓存, 按级别排序, 没有找到的跳过
+func (p *ProcessorInfo) Caches() []*CacheInfo {
+	return p.caches
+}
+
+func (p *ProcessorInfo) linkCaches(caches map[Handle]*CacheInfo) {
+	p.caches = nil
+	for _, value := range []string{p.L1CacheHandle, p.L2CacheHandle, p.L3CacheHandle} {
+		if handle, ok := parseHandle(value); ok && caches[handle] != nil {
+			p.caches = append(p.caches, caches[handle])
+		}
+	}
+}
+
+// 主板所在的机箱, 记录中没有对应的Chassis Information时返回nil
+func (b *BaseBoardInfo) Chassis() *ChassisInfo {
+	return b.chassis
+}
+
+func chassisByHandle(records []Record, value string) *ChassisInfo {
+	handle, ok := parseHandle(value)
+	if !ok {
+		return nil
+	}
+	for index := range records {
+		record := &records[index]
+		if record.Type >= 0 && record.Handle == handle && record.Name == "Chassis Information" {
+			return chassisFromRecords(records[index : index+1])
+		}
+	}
+	return nil
+}
@@ -0,0 +1,169 @@
+package dmidecode
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestInventory_Handles(t *testing.T) {
+	inventory, err := ParseAll(openTestdata(t, "thinkpad.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name    string
+		handle  Handle
+		dmiType int
+		want    Handle
+		wantT   int
+	}{
+		{"bios", inventory.BIOS.Handle, inventory.BIOS.DMIType, 0x0000, 0},
+		{"system", inventory.System.Handle, inventory.System.DMIType, 0x0001, 1},
+		{"baseboard", inventory.BaseBoard.Handle, inventory.BaseBoard.DMIType, 0x0002, 2},
+		{"chassis", inventory.Chassis.Handle, inventory.Chassis.DMIType, 0x0003, 3},
+		{"processor", inventory.Processors[0].Handle, inventory.Processors[0].DMIType, 0x0004, 4},
+		{"memory", inventory.Memory.Handle, inventory.Memory.DMIType, 0x0005, 16},
+		{"cache", inventory.Cache[0].Handle, inventory.Cache[0].DMIType, 0x0006, 7},
+		{"device", inventory.Memory.MemoryList[1].Handle, inventory.Memory.MemoryList[1].DMIType, 0x000A, 17},
+		{"connector", inventory.Connector[0].Handle, inventory.Connector[0].DMIType, 0x000B, 8},
+		{"slot", inventory.Slot[0].Handle, inventory.Slot[0].DMIType, 0x000D, 9},
+		{"language", inventory.BIOSLanguage.Handle, inventory.BIOSLanguage.DMIType, 0x000E, 13},
+	}
+	for _, test := range tests {
+		if test.handle != test.want || test.dmiType != test.wantT {
+			t.Errorf("%s: got %s type %d, want %s type %d", test.name, test.handle, test.dmiType, test.want, test.wantT)
+		}
+	}
+	if len(inventory.Dangling) != 0 {
+		t.Errorf("dangling: %+v", inventory.Dangling)
+	}
+}
+
+func TestProcessorInfo_Caches(t *testing.T) {
+	inventory, err := ParseAll(openTestdata(t, "thinkpad.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	caches := inventory.Processors[0].Caches()
+	var names []string
+	for _, cache := range caches {
+		names = append(names, cache.SocketDesignation)
+	}
+	if want := []string{"L1-Cache", "L2-Cache", "L3-Cache"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("caches: got %v, want %v", names, want)
+	}
+
+	// 只有 -t processor 的输出中没有cache
+	processors, err := ParseProcessors(openTestdata(t, "server-4socket.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if caches := processors[0].Caches(); caches != nil {
+		t.Errorf("caches: %+v", caches)
+	}
+}
+
+func TestBaseBoardInfo_Chassis(t *testing.T) {
+	// thinkpad.txt 中的Chassis Handle指向BIOS, 不是机箱
+	inventory, err := ParseAll(openTestdata(t, "thinkpad.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if chassis := inventory.BaseBoard.Chassis(); chassis != nil {
+		t.Errorf("chassis: %+v", chassis)
+	}
+
+	table, err := ReadSysfs("testdata/sysfs")
+	if err != nil {
+		t.Fatal(err)
+	}
+	baseBoard, err := table.QueryBaseBoard()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if chassis := baseBoard.Chassis(); chassis == nil || chassis.Handle != 0x0003 {
+		t.Errorf("chassis: %+v", chassis)
+	}
+}
+
+func TestMemoryDevice_Array(t *testing.T) {
+	arrays, err := ParseMemoryArrays(openTestdata(t, "server-memory.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, array := range arrays {
+		for _, device := range array.Devices {
+			if device.Array() != array {
+				t.Errorf("device %s: array %p, want %p", device.Handle, device.Array(), array)
+			}
+		}
+	}
+}
+
+func TestDanglingReferences(t *testing.T) {
+	records := ParseRecords(readTestdata(t, "server-memory.txt"))
+	want := []Reference{{From: 0x1105, Name: "Memory Device", Field: "Array Handle", To: 0x1FFF}}
+	if got := DanglingReferences(records); !reflect.DeepEqual(got, want) {
+		t.Errorf("dangling: got %+v, want %+v", got, want)
+	}
+}
+
+// Inventory中的引用指向同一个对象, 而不是另外解码的副本
+func TestInventory_SharedReferences(t *testing.T) {
+	inventory := inventoryFromRecords(ParseRecords(readTestdata(t, "thinkpad.txt")))
+	if len(inventory.Processors) == 0 || len(inventory.Processors[0].Caches()) == 0 {
+		t.Fatalf("processors: %+v", inventory.Processors)
+	}
+	for _, cache := range inventory.Processors[0].Caches() {
+		found := false
+		for _, item := range inventory.Cache {
+			found = found || item == cache
+		}
+		if !found {
+			t.Errorf("cache %s is not in Inventory.Cache", cache.Handle)
+		}
+	}
+
+	inventory = inventoryFromRecords(ParseRecords(readTestdata(t, "server-memory.txt")))
+	if len(inventory.Memory.MemoryList) == 0 || inventory.Memory.MemoryList[0].Array() == nil {
+		t.Fatalf("memory: %+v", inventory.Memory)
+	}
+	for _, device := range inventory.Memory.MemoryList {
+		array := device.Array()
+		if array == nil {
+			continue
+		}
+		found := false
+		for _, item := range inventory.MemoryArrays {
+			found = found || item == array
+		}
+		if !found {
+			t.Errorf("array of %s is not in Inventory.MemoryArrays", device.Handle)
+		}
+	}
+
+	inventory = inventoryFromRecords(ParseRecords(readTestdata(t, "probes.txt")))
+	var linked int
+	for _, device := range inventory.CoolingDevices {
+		probe := device.TemperatureProbe()
+		if probe == nil {
+			continue
+		}
+		linked++
+		found := false
+		for _, item := range inventory.TemperatureProbes {
+			found = found || item == probe
+		}
+		if !found {
+			t.Errorf("probe of %s is not in Inventory.TemperatureProbes", device.Handle)
+		}
+	}
+	if linked == 0 {
+		t.Error("no cooling device with a temperature probe")
+	}
+
+	inventory = inventoryFromRecords(ParseRecords(readTestdata(t, "colons/synthetic-supermicro.txt")))
+	if inventory.BaseBoard.Chassis() != inventory.Chassis {
+		t.Errorf("chassis: %p %p", inventory.BaseBoard.Chassis(), inventory.Chassis)
+	}
+}
@@ -0,0 +1,139 @@
+package dmidecode
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+)
+
+// 执行命令的接口, args[0]为要执行的程序
+type Runner interface {
+	Run(ctx context.Context, args []string) ([]byte, error)
+}
+
+// 把普通函数转换为Runner
+type RunnerFunc func(ctx context.Context, args []string) ([]byte, error)
+
+func (f RunnerFunc) Run(ctx context.Context, args []string) ([]byte, error) {
+	return f(ctx, args)
+}
+
+var errNoCommand = errors.New("dmidecode: empty command")
+
+// 在本机直接执行, 参数不经过shell
+type ExecRunner struct{}
+
+func (ExecRunner) Run(ctx context.Context, args []string) ([]byte, error) {
+	return runCommand(ctx, args, nil)
+}
+
+// ctx取消后等待子进程退出的时间, 超过后强制结束
+var killDelay = 2 * time.Second
+
+// 执行命令, stdin不为空时写入子进程的标准输入.
+// 子进程在单独的进程组中, ctx取消时先向整个进程组发SIGTERM(sudo也会转发给dmidecode),
+// killDelay后仍未退出则发SIGKILL; 输出管道也在killDelay后关闭, 不会留下等待的goroutine
+func runCommand(ctx context.Context, args []string, stdin []byte) ([]byte, error) {
+	if len(args) == 0 {
+		return nil, errNoCommand
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, args[0], args[1:]...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if stdin != nil {
+		cmd.Stdin = bytes.NewReader(stdin)
+	}
+	setProcessGroup(cmd)
+	cmd.Cancel = func() error {
+		return terminateProcessGroup(cmd)
+	}
+	cmd.WaitDelay = killDelay
+
+	err := cmd.Run()
+	if ctx.Err() != nil {
+		if cmd.Process != nil {
+			// 进程组中可能还有没有退出的子进程
+			killProcessGroup(cmd)
+		}
+		return nil, ctx.Err()
+	}
+	if err != nil {
+		return stdout.Bytes(), &CommandError{Args: args, Err: err, Stderr: strings.TrimSpace(stderr.String())}
+	}
+	return stdout.Bytes(), nil
+}
+
+// 查询超时, 子进程已被结束
+var ErrTimeout = errors.New("dmidecode: query timed out")
+
+// ctx超时返回ErrTimeout, 被取消返回context.Canceled, 否则原样返回err
+func contextError(ctx context.Context, err error) error {
+	switch ctx.Err() {
+	case context.DeadlineExceeded:
+		return ErrTimeout
+	case context.Canceled:
+		return context.Canceled
+	}
+	return err
+}
+
+// 命令执行失败, Stderr为子进程的错误输出
+type CommandError struct {
+	Args   []string
+	Err    error
+	Stderr string
+}
+
+func (e *CommandError) Error() string {
+	if e.Stderr != "" {
+		return fmt.Sprintf("%s: %v: %s", e.Args[0], e.Err, e.Stderr)
+	}
+	return fmt.Sprintf("%s: %v", e.Args[0], e.Err)
+}
+
+func (e *CommandError) Unwrap() error {
+	return e.Err
+}
+
+// 回放预先录制的输出, key为用空格连接的参数, 例如 "dmidecode -t bios"
+type ReplayRunner map[string][]byte
+
+func (r ReplayRunner) Run(ctx context.Context, args []string) ([]byte, error) {
+	key := strings.Join(args, " ")
+	output, ok := r[key]
+	if !ok {
+		return nil, fmt.Errorf("dmidecode: no recorded output for %q", key)
+	}
+	return output, nil
+}
+
+// 执行的同时把输出录制到Replay中, 之后可以用ReplayRunner回放
+type RecordRunner struct {
+	Runner Runner
+	Replay ReplayRunner
+
+	mutex sync.Mutex
+}
+
+func (r *RecordRunner) Run(ctx context.Context, args []string) ([]byte, error) {
+	output, err := r.Runner.Run(ctx, args)
+	if err != nil {
+		return output, err
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if r.Replay == nil {
+		r.Replay = make(ReplayRunner)
+	}
+	r.Replay[strings.Join(args, " ")] = output
+	return output, nil
+}
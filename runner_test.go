@@ -0,0 +1,118 @@
+package dmidecode
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestReplayRunner_Missing(t *testing.T) {
+	d := &DmiDecode{Path: "dmidecode", IsActive: true, Runner: ReplayRunner{}}
+	if _, err := d.QuerySlot(); err == nil {
+		t.Error("expected error for missing recording")
+	}
+}
+
+func TestRecordRunner(t *testing.T) {
+	var calls [][]string
+	fake := RunnerFunc(func(ctx context.Context, args []string) ([]byte, error) {
+		calls = append(calls, args)
+		return []byte("Handle 0x0000, DMI type 3, 22 bytes\nChassis Information\n\tType: Desktop\n"), nil
+	})
+	recorder := &RecordRunner{Runner: fake}
+	d := &DmiDecode{Path: "/usr/sbin/dmidecode", IsActive: true, Runner: recorder}
+	if _, err := d.QueryChassis(); err != nil {
+		t.Fatal(err)
+	}
+	if want := [][]string{{"/usr/sbin/dmidecode", "-t", "chassis"}}; !reflect.DeepEqual(calls, want) {
+		t.Errorf("calls: got %q, want %q", calls, want)
+	}
+
+	d.Runner = recorder.Replay
+	chassis, err := d.QueryChassis()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if chassis.Type != ChassisDesktop {
+		t.Errorf("chassis: %+v", chassis)
+	}
+}
+
+func TestExecRunner(t *testing.T) {
+	output, err := ExecRunner{}.Run(context.Background(), []string{"echo", "a;b", "$HOME"})
+	if err != nil {
+		t.Skip(err)
+	}
+	if string(output) != "a;b $HOME\n" {
+		t.Errorf("output: %q", output)
+	}
+	if _, err := (ExecRunner{}).Run(context.Background(), nil); err == nil {
+		t.Error("expected error for empty command")
+	}
+}
+
+func TestExecRunner_Timeout(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	d := &DmiDecode{Path: "dmidecode", IsActive: true}
+	// 模拟卡住的dmidecode
+	d.Runner = RunnerFunc(func(ctx context.Context, args []string) ([]byte, error) {
+		return ExecRunner{}.Run(ctx, []string{"sleep", "10"})
+	})
+	_, err := d.QueryProcessorContext(ctx)
+	if err != ErrTimeout {
+		t.Errorf("got %v, want ErrTimeout", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("child not killed, took %v", elapsed)
+	}
+}
+
+// sudo等中间进程启动的子进程也要被结束
+func TestExecRunner_TimeoutKillsGrandchild(t *testing.T) {
+	if _, err := os.Stat("/proc/self/stat"); err != nil {
+		t.Skip("no /proc")
+	}
+	pidFile := filepath.Join(t.TempDir(), "pid")
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	_, err := ExecRunner{}.Run(ctx, []string{"sh", "-c", "sleep 30 & echo $! > " + pidFile + "; wait"})
+	if err != context.DeadlineExceeded {
+		t.Fatalf("got %v, want context.DeadlineExceeded", err)
+	}
+	pid, err := ioutil.ReadFile(pidFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		stat, err := ioutil.ReadFile("/proc/" + strings.TrimSpace(string(pid)) + "/stat")
+		// 进程不存在或者已经是僵尸进程
+		if err != nil || strings.Contains(string(stat), ") Z ") {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("grandchild %s still running", strings.TrimSpace(string(pid)))
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+}
+
+func TestQueryContext_Canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	d := replayInstance(t)
+	d.Runner = RunnerFunc(func(ctx context.Context, args []string) ([]byte, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	})
+	if _, _, err := d.QueryBIOSContext(ctx); err != context.Canceled {
+		t.Errorf("got %v, want context.Canceled", err)
+	}
+}
@@ -0,0 +1,15 @@
+package dmidecode
+
+import "os/exec"
+
+// windows没有进程组信号, 只结束子进程本身
+
+func setProcessGroup(cmd *exec.Cmd) {}
+
+func terminateProcessGroup(cmd *exec.Cmd) error {
+	return cmd.Process.Kill()
+}
+
+func killProcessGroup(cmd *exec.Cmd) error {
+	return cmd.Process.Kill()
+}
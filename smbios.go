@@ -0,0 +1,253 @@
+package dmidecode
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+)
+
+// 不依赖dmidecode命令, 直接读取 /sys/firmware/dmi/tables 下的SMBIOS表
+
+const (
+	sysfsEntryPoint = "sys/firmware/dmi/tables/smbios_entry_point"
+	sysfsTable      = "sys/firmware/dmi/tables/DMI"
+)
+
+var (
+	ErrEntryPoint = errors.New("dmidecode: invalid SMBIOS entry point")
+	ErrTable      = errors.New("dmidecode: invalid SMBIOS structure table")
+)
+
+// SMBIOS入口点, 支持32位的 _SM_ 和64位的 _SM3_
+type EntryPoint struct {
+	Anchor         string // _SM_, _SM3_ 或 _DMI_
+	Major          uint8
+	Minor          uint8
+	Revision       uint8  // _SM3_ 的docrev
+	TableAddress   uint64 // 结构表的物理地址
+	TableLength    uint32 // 结构表的长度, _SM3_ 为最大长度
+	StructureCount uint16 // 结构数量, _SM3_ 没有这个字段
+}
+
+// SMBIOS版本, 例如2.7返回0x0207
+func (e *EntryPoint) Version() int {
+	return int(e.Major)<<8 | int(e.Minor)
+}
+
+func (e *EntryPoint) String() string {
+	if e.Anchor == "_SM3_" {
+		return fmt.Sprintf("SMBIOS %d.%d.%d", e.Major, e.Minor, e.Revision)
+	}
+	return fmt.Sprintf("SMBIOS %d.%d", e.Major, e.Minor)
+}
+
+func checksum(data []byte) bool {
+	var sum uint8
+	for _, b := range data {
+		sum += b
+	}
+	return sum == 0
+}
+
+// 解析入口点并校验checksum
+func ParseEntryPoint(data []byte) (*EntryPoint, error) {
+	switch {
+	case bytes.HasPrefix(data, []byte("_SM3_")):
+		if len(data) < 0x18 || int(data[0x06]) < 0x18 || len(data) < int(data[0x06]) {
+			return nil, ErrEntryPoint
+		}
+		if !checksum(data[:data[0x06]]) {
+			return nil, fmt.Errorf("%v: checksum mismatch", ErrEntryPoint)
+		}
+		return &EntryPoint{
+			Anchor:       "_SM3_",
+			Major:        data[0x07],
+			Minor:        data[0x08],
+			Revision:     data[0x09],
+			TableLength:  binary.LittleEndian.Uint32(data[0x0C:]),
+			TableAddress: binary.LittleEndian.Uint64(data[0x10:]),
+		}, nil
+	case bytes.HasPrefix(data, []byte("_SM_")):
+		// SMBIOS 2.1 规范把长度错写成了0x1E, 部分BIOS照此实现, 这时可能只有30个字节,
+		// 缺少中间入口点的最后一个字节, 无法校验中间入口点的checksum
+		if len(data) < 0x1E {
+			return nil, ErrEntryPoint
+		}
+		length := int(data[0x05])
+		if length < 0x1E || len(data) < length {
+			return nil, ErrEntryPoint
+		}
+		if !checksum(data[:length]) || !bytes.Equal(data[0x10:0x15], []byte("_DMI_")) {
+			return nil, fmt.Errorf("%v: checksum mismatch", ErrEntryPoint)
+		}
+		if len(data) >= 0x1F && !checksum(data[0x10:0x1F]) {
+			return nil, fmt.Errorf("%v: checksum mismatch", ErrEntryPoint)
+		}
+		return &EntryPoint{
+			Anchor:         "_SM_",
+			Major:          data[0x06],
+			Minor:          data[0x07],
+			TableLength:    uint32(binary.LittleEndian.Uint16(data[0x16:])),
+			TableAddress:   uint64(binary.LittleEndian.Uint32(data[0x18:])),
+			StructureCount: binary.LittleEndian.Uint16(data[0x1C:]),
+		}, nil
+	case bytes.HasPrefix(data, []byte("_DMI_")):
+		if len(data) < 0x0F || !checksum(data[:0x0F]) {
+			return nil, ErrEntryPoint
+		}
+		return &EntryPoint{
+			Anchor:         "_DMI_",
+			Major:          data[0x0E] >> 4,
+			Minor:          data[0x0E] & 0x0F,
+			TableLength:    uint32(binary.LittleEndian.Uint16(data[0x06:])),
+			TableAddress:   uint64(binary.LittleEndian.Uint32(data[0x08:])),
+			StructureCount: binary.LittleEndian.Uint16(data[0x0C:]),
+		}, nil
+	}
+	return nil, ErrEntryPoint
+}
+
+// SMBIOS结构表中的一个结构
+type Structure struct {
+	Type    uint8
+	Length  uint8
+	Handle  Handle
+	Data    []byte // 格式化区域, 包含4字节的头
+	Strings []string
+}
+
+// 取字符串, 序号从1开始, 0表示没有设置
+func (s *Structure) String(index uint8) (string, bool) {
+	if index == 0 || int(index) > len(s.Strings) {
+		return "", false
+	}
+	return s.Strings[index-1], true
+}
+
+// 解析结构表, count为0时一直读到 End Of Table 或表尾
+func ParseStructures(table []byte, count int) ([]*Structure, error) {
+	var result []*Structure
+	offset := 0
+	for offset+4 <= len(table) && (count == 0 || len(result) < count) {
+		s := &Structure{
+			Type:   table[offset],
+			Length: table[offset+1],
+			Handle: Handle(binary.LittleEndian.Uint16(table[offset+2:])),
+		}
+		if s.Length < 4 || offset+int(s.Length) > len(table) {
+			return result, fmt.Errorf("%v: bad length %d at handle %s", ErrTable, s.Length, s.Handle)
+		}
+		s.Data = table[offset : offset+int(s.Length)]
+		// 字符串区域以两个0结束
+		strs := table[offset+int(s.Length):]
+		end := bytes.Index(strs, []byte{0, 0})
+		if end < 0 {
+			return result, fmt.Errorf("%v: unterminated strings at handle %s", ErrTable, s.Handle)
+		}
+		if end > 0 {
+			for _, str := range bytes.Split(strs[:end], []byte{0}) {
+				s.Strings = append(s.Strings, string(str))
+			}
+		}
+		result = append(result, s)
+		offset += int(s.Length) + end + 2
+		if s.Type == 127 {
+			break
+		}
+	}
+	return result, nil
+}
+
+// 解析后的SMBIOS表
+type Table struct {
+	EntryPoint *EntryPoint
+	Structures []*Structure
+	// 为true时保留占位符, 见DmiDecode.KeepPlaceholders
+	KeepPlaceholders bool
+}
+
+// 解析入口点和结构表
+func ParseTable(entry, table []byte) (*Table, error) {
+	entryPoint, err := ParseEntryPoint(entry)
+	if err != nil {
+		return nil, err
+	}
+	if entryPoint.TableLength > 0 && int(entryPoint.TableLength) < len(table) {
+		table = table[:entryPoint.TableLength]
+	}
+	structures, err := ParseStructures(table, int(entryPoint.StructureCount))
+	if err != nil {
+		return nil, err
+	}
+	return &Table{EntryPoint: entryPoint, Structures: structures}, nil
+}
+
+// 读取 root/sys/firmware/dmi/tables 下的SMBIOS表, root为空时使用"/"
+func ReadSysfs(root string) (*Table, error) {
+	if root == "" {
+		root = "/"
+	}
+	entry, err := ioutil.ReadFile(filepath.Join(root, sysfsEntryPoint))
+	if err != nil {
+		return nil, err
+	}
+	table, err := ioutil.ReadFile(filepath.Join(root, sysfsTable))
+	if err != nil {
+		return nil, err
+	}
+	return ParseTable(entry, table)
+}
+
+// 把所有结构解码为与dmidecode文本输出一致的记录
+func (t *Table) Records() []Record {
+	result := make([]Record, 0, len(t.Structures))
+	for _, s := range t.Structures {
+		for _, record := range decodeStructure(s, t.EntryPoint.Version()) {
+			record.keepPlaceholders = t.KeepPlaceholders
+			record.version = t.EntryPoint.Version()
+			record.structure = s
+			result = append(result, record)
+		}
+	}
+	return result
+}
+
+func (t *Table) QueryBIOS() (*BiosInfo, *BiosLanguage, error) {
+	result, language := biosFromRecords(t.Records())
+	return result, language, nil
+}
+
+func (t *Table) QuerySystem() (*SystemInfo, error) {
+	return systemFromRecords(t.Records()), nil
+}
+
+func (t *Table) QueryBaseBoard() (*BaseBoardInfo, error) {
+	return baseBoardFromRecords(t.Records()), nil
+}
+
+func (t *Table) QueryChassis() (*ChassisInfo, error) {
+	return chassisFromRecords(t.Records()), nil
+}
+
+func (t *Table) QueryProcessor() (*ProcessorInfo, error) {
+	return processorFromRecords(t.Records()), nil
+}
+
+func (t *Table) QueryMemory() (*MemoryInfo, error) {
+	return memoryFromRecords(t.Records()), nil
+}
+
+func (t *Table) QueryCache() ([]*CacheInfo, error) {
+	return cacheFromRecords(t.Records()), nil
+}
+
+func (t *Table) QueryConnector() ([]*PortConnectorInfo, error) {
+	return connectorFromRecords(t.Records()), nil
+}
+
+func (t *Table) QuerySlot() ([]*SystemSlotInfo, error) {
+	return slotFromRecords(t.Records()), nil
+}
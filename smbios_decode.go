@@ -0,0 +1,952 @@
+package dmidecode
+
+import (
+	"encoding/binary"
+	"fmt"
+	"strings"
+)
+
+// 把二进制结构解码为记录, 字段名和值的格式与dmidecode 3.0的输出一致,
+// 这样文本和二进制两种来源可以共用同一套解析
+
+type decoder struct {
+	s       *Structure
+	version int // SMBIOS版本, 例如0x0207
+	fields  []Field
+}
+
+func (d *decoder) has(length int) bool {
+	return int(d.s.Length) >= length
+}
+
+func (d *decoder) byte(offset int) uint8 {
+	return d.s.Data[offset]
+}
+
+func (d *decoder) word(offset int) uint16 {
+	return binary.LittleEndian.Uint16(d.s.Data[offset:])
+}
+
+func (d *decoder) dword(offset int) uint32 {
+	return binary.LittleEndian.Uint32(d.s.Data[offset:])
+}
+
+func (d *decoder) qword(offset int) uint64 {
+	return binary.LittleEndian.Uint64(d.s.Data[offset:])
+}
+
+// 与dmidecode的dmi_string一致, 不可打印字符替换为'.'
+func (d *decoder) str(offset int) string {
+	return d.strIndex(d.byte(offset))
+}
+
+// 按序号取字符串, 用于OEM Strings等直接以序号列出字符串的结构
+func (d *decoder) strIndex(index uint8) string {
+	if index == 0 {
+		return "Not Specified"
+	}
+	value, ok := d.s.String(index)
+	if !ok {
+		return "<BAD INDEX>"
+	}
+	return strings.Map(func(r rune) rune {
+		if r < 32 || r == 127 {
+			return '.'
+		}
+		return r
+	}, value)
+}
+
+func (d *decoder) add(key, value string) {
+	d.fields = append(d.fields, Field{Key: key, Value: value})
+}
+
+func (d *decoder) addf(key, format string, args ...interface{}) {
+	d.add(key, fmt.Sprintf(format, args...))
+}
+
+func (d *decoder) addList(key, value string, list []string) {
+	d.fields = append(d.fields, Field{Key: key, Value: value, List: list})
+}
+
+// 按位取名称, names[i]对应第offset+i位
+func bitNames(code uint64, offset uint, names []string) []string {
+	var result []string
+	for index, name := range names {
+		if name != "" && code&(1<<(offset+uint(index))) != 0 {
+			result = append(result, name)
+		}
+	}
+	return result
+}
+
+func lookup(table map[int]string, code int) string {
+	if name, ok := table[code]; ok {
+		return name
+	}
+	return "<OUT OF SPEC>"
+}
+
+// 与dmidecode的dmi_print_memory_size一致, 取能整除的最大单位
+func formatMemorySize(size uint64) string {
+	units := []string{"bytes", "kB", "MB", "GB", "TB", "PB", "EB"}
+	var split [7]uint64
+	for index := range split {
+		split[index] = size & 0x3FF
+		size >>= 10
+	}
+	index := len(split) - 1
+	for index > 0 && split[index] == 0 {
+		index--
+	}
+	if index > 0 && split[index-1] != 0 {
+		index--
+		return fmt.Sprintf("%d %s", split[index]+split[index+1]<<10, units[index])
+	}
+	return fmt.Sprintf("%d %s", split[index], units[index])
+}
+
+func structureName(t uint8) string {
+	if name, ok := structureNames[int(t)]; ok {
+		return name
+	}
+	if t >= 128 {
+		return "OEM-specific Type"
+	}
+	return "Unknown Type"
+}
+
+func decodeStructure(s *Structure, version int) []Record {
+	d := &decoder{s: s, version: version}
+	record := Record{Handle: s.Handle, Type: int(s.Type), Size: int(s.Length), Name: structureName(s.Type)}
+	switch s.Type {
+	case 0:
+		d.bios()
+	case 1:
+		d.system()
+	case 2:
+		d.baseBoard()
+	case 3:
+		d.chassis()
+	case 4:
+		d.processor()
+	case 7:
+		d.cache()
+	case 8:
+		d.connector()
+	case 9:
+		d.slot()
+	case 10:
+		return d.onBoardDevices(record)
+	case 11:
+		d.oemStrings()
+	case 12:
+		d.configurationOptions()
+	case 13:
+		d.biosLanguage()
+	case 16:
+		d.memoryArray()
+	case 17:
+		d.memoryDevice()
+	case 22:
+		d.portableBattery()
+	case 26:
+		d.probe(voltageProbeLocations, "%.3f V", 1000, "%.1f mV", 10)
+	case 27:
+		d.coolingDevice()
+	case 28:
+		d.probe(temperatureProbeLocations, "%.1f deg C", 10, "%.3f deg C", 1000)
+	case 29:
+		d.probe(voltageProbeLocations, "%.3f A", 1000, "%.1f mA", 10)
+	case 41:
+		d.onboardDevice()
+	case 126, 127:
+	default:
+		d.dump()
+	}
+	record.Fields = d.fields
+	return []Record{record}
+}
+
+// 没有解码的结构输出原始数据
+func (d *decoder) dump() {
+	var lines []string
+	for offset := 0; offset < len(d.s.Data); offset += 16 {
+		end := offset + 16
+		if end > len(d.s.Data) {
+			end = len(d.s.Data)
+		}
+		line := make([]string, 0, 16)
+		for _, b := range d.s.Data[offset:end] {
+			line = append(line, fmt.Sprintf("%02X", b))
+		}
+		lines = append(lines, strings.Join(line, " "))
+	}
+	d.addList("Header and Data", "", lines)
+	if len(d.s.Strings) > 0 {
+		d.addList("Strings", "", append([]string(nil), d.s.Strings...))
+	}
+}
+
+// type 0
+func (d *decoder) bios() {
+	if !d.has(0x12) {
+		return
+	}
+	d.add("Vendor", d.str(0x04))
+	d.add("Version", d.str(0x05))
+	d.add("Release Date", d.str(0x08))
+	if address := d.word(0x06); address != 0 {
+		d.addf("Address", "0x%04X0", address)
+		runtime := (0x10000 - uint32(address)) << 4
+		if runtime&0x3FF != 0 {
+			d.addf("Runtime Size", "%d bytes", runtime)
+		} else {
+			d.addf("Runtime Size", "%d kB", runtime>>10)
+		}
+	}
+	if d.byte(0x09) == 0xFF && d.has(0x1A) {
+		size := d.word(0x18)
+		if size&0xC000 == 0 {
+			d.addf("ROM Size", "%d MB", size&0x3FFF)
+		} else {
+			d.addf("ROM Size", "%d GB", size&0x3FFF)
+		}
+	} else {
+		d.addf("ROM Size", "%d kB", (uint32(d.byte(0x09))+1)<<6)
+	}
+	var characteristics []string
+	code := d.qword(0x0A)
+	if code&(1<<3) != 0 {
+		characteristics = []string{"BIOS characteristics not supported"}
+	} else {
+		characteristics = bitNames(code, 4, biosCharacteristics)
+		if d.has(0x13) {
+			characteristics = append(characteristics, bitNames(uint64(d.byte(0x12)), 0, biosCharacteristicsExt1)...)
+		}
+		if d.has(0x14) {
+			characteristics = append(characteristics, bitNames(uint64(d.byte(0x13)), 0, biosCharacteristicsExt2)...)
+		}
+	}
+	d.addList("Characteristics", "", characteristics)
+	if !d.has(0x18) {
+		return
+	}
+	if d.byte(0x14) != 0xFF && d.byte(0x15) != 0xFF {
+		d.addf("BIOS Revision", "%d.%d", d.byte(0x14), d.byte(0x15))
+	}
+	if d.byte(0x16) != 0xFF && d.byte(0x17) != 0xFF {
+		d.addf("Firmware Revision", "%d.%d", d.byte(0x16), d.byte(0x17))
+	}
+}
+
+// type 1
+func (d *decoder) system() {
+	if !d.has(0x08) {
+		return
+	}
+	d.add("Manufacturer", d.str(0x04))
+	d.add("Product Name", d.str(0x05))
+	d.add("Version", d.str(0x06))
+	d.add("Serial Number", d.str(0x07))
+	if !d.has(0x19) {
+		return
+	}
+	d.add("UUID", formatSystemUUID(d.s.Data[0x08:0x18], d.version))
+	d.add("Wake-up Type", lookup(wakeUpTypes, int(d.byte(0x18))))
+	if !d.has(0x1B) {
+		return
+	}
+	d.add("SKU Number", d.str(0x19))
+	d.add("Family", d.str(0x1A))
+}
+
+// 与dmidecode的dmi_system_uuid一致, 2.6之前的版本前三段没有按小端存储
+func formatSystemUUID(p []byte, version int) string {
+	uuid, _ := UUIDFromBytes(p, version)
+	switch {
+	case uuid.IsZero():
+		return "Not Settable"
+	case uuid.IsPlaceholder() && uuid[0] == 0xFF:
+		return "Not Present"
+	}
+	return strings.ToUpper(uuid.String())
+}
+
+// type 2
+func (d *decoder) baseBoard() {
+	if !d.has(0x08) {
+		return
+	}
+	d.add("Manufacturer", d.str(0x04))
+	d.add("Product Name", d.str(0x05))
+	d.add("Version", d.str(0x06))
+	d.add("Serial Number", d.str(0x07))
+	if !d.has(0x09) {
+		return
+	}
+	d.add("Asset Tag", d.str(0x08))
+	if !d.has(0x0A) {
+		return
+	}
+	if features := d.byte(0x09); features&0x1F == 0 {
+		d.add("Features", "None")
+	} else {
+		d.addList("Features", "", bitNames(uint64(features), 0, baseBoardFeatures))
+	}
+	if !d.has(0x0E) {
+		return
+	}
+	d.add("Location In Chassis", d.str(0x0A))
+	d.addf("Chassis Handle", "0x%04X", d.word(0x0B))
+	d.add("Type", lookup(baseBoardTypes, int(d.byte(0x0D))))
+	if !d.has(0x0F) || !d.has(0x0F+int(d.byte(0x0E))*2) {
+		return
+	}
+	count := int(d.byte(0x0E))
+	var handles []string
+	for index := 0; index < count; index++ {
+		handles = append(handles, fmt.Sprintf("0x%04X", d.word(0x0F+index*2)))
+	}
+	d.addList("Contained Object Handles", fmt.Sprintf("%d", count), handles)
+}
+
+// type 3
+func (d *decoder) chassis() {
+	if !d.has(0x09) {
+		return
+	}
+	d.add("Manufacturer", d.str(0x04))
+	d.add("Type", lookup(chassisTypes, int(d.byte(0x05)&0x7F)))
+	if d.byte(0x05)&0x80 != 0 {
+		d.add("Lock", "Present")
+	} else {
+		d.add("Lock", "Not Present")
+	}
+	d.add("Version", d.str(0x06))
+	d.add("Serial Number", d.str(0x07))
+	d.add("Asset Tag", d.str(0x08))
+	if !d.has(0x0D) {
+		return
+	}
+	d.add("Boot-up State", lookup(chassisStates, int(d.byte(0x09))))
+	d.add("Power Supply State", lookup(chassisStates, int(d.byte(0x0A))))
+	d.add("Thermal State", lookup(chassisStates, int(d.byte(0x0B))))
+	d.add("Security Status", lookup(chassisSecurityStatus, int(d.byte(0x0C))))
+	if !d.has(0x11) {
+		return
+	}
+	d.addf("OEM Information", "0x%08X", d.dword(0x0D))
+	if !d.has(0x13) {
+		return
+	}
+	if height := d.byte(0x11); height == 0 {
+		d.add("Height", "Unspecified")
+	} else {
+		d.addf("Height", "%d U", height)
+	}
+	if cords := d.byte(0x12); cords == 0 {
+		d.add("Number Of Power Cords", "Unspecified")
+	} else {
+		d.addf("Number Of Power Cords", "%d", cords)
+	}
+	if !d.has(0x15) {
+		return
+	}
+	count, size := int(d.byte(0x13)), int(d.byte(0x14))
+	if !d.has(0x15 + count*size) {
+		return
+	}
+	var elements []string
+	if size >= 3 {
+		for index := 0; index < count; index++ {
+			offset := 0x15 + index*size
+			var name string
+			if code := d.byte(offset); code&0x80 != 0 {
+				name = lookup(structureTypeNames, int(code&0x7F))
+			} else {
+				name = lookup(baseBoardTypes, int(code&0x7F))
+			}
+			if low, high := d.byte(offset+1), d.byte(offset+2); low == high {
+				elements = append(elements, fmt.Sprintf("%s (%d)", name, low))
+			} else {
+				elements = append(elements, fmt.Sprintf("%s (%d-%d)", name, low, high))
+			}
+		}
+	}
+	d.addList("Contained Elements", fmt.Sprintf("%d", count), elements)
+	if !d.has(0x16 + count*size) {
+		return
+	}
+	d.add("SKU Number", d.str(0x15+count*size))
+}
+
+// type 4
+func (d *decoder) processor() {
+	if !d.has(0x1A) {
+		return
+	}
+	d.add("Socket Designation", d.str(0x04))
+	d.add("Type", lookup(processorTypes, int(d.byte(0x05))))
+	family := int(d.byte(0x06))
+	if family == 0xFE && d.has(0x2A) {
+		family = int(d.word(0x28))
+	}
+	d.add("Family", lookup(processorFamilies, family))
+	d.add("Manufacturer", d.str(0x07))
+	d.processorID(family)
+	d.add("Version", d.str(0x10))
+	voltage := d.byte(0x11)
+	switch {
+	case voltage&0x80 != 0:
+		d.addf("Voltage", "%.1f V", float64(voltage&0x7F)/10)
+	case voltage&0x07 == 0:
+		d.add("Voltage", "Unknown")
+	default:
+		d.add("Voltage", strings.Join(bitNames(uint64(voltage), 0, []string{"5.0 V", "3.3 V", "2.9 V"}), " "))
+	}
+	d.add("External Clock", formatMHz(d.word(0x12)))
+	d.add("Max Speed", formatMHz(d.word(0x14)))
+	d.add("Current Speed", formatMHz(d.word(0x16)))
+	if status := d.byte(0x18); status&0x40 != 0 {
+		d.add("Status", "Populated, "+lookup(processorStatus, int(status&0x07)))
+	} else {
+		d.add("Status", "Unpopulated")
+	}
+	d.add("Upgrade", lookup(processorUpgrades, int(d.byte(0x19))))
+	if !d.has(0x20) {
+		return
+	}
+	for index, key := range []string{"L1 Cache Handle", "L2 Cache Handle", "L3 Cache Handle"} {
+		handle := d.word(0x1A + index*2)
+		switch {
+		case handle != 0xFFFF:
+			d.addf(key, "0x%04X", handle)
+		case d.version >= 0x0203:
+			d.add(key, "Not Provided")
+		default:
+			d.addf(key, "No L%d Cache", index+1)
+		}
+	}
+	if !d.has(0x23) {
+		return
+	}
+	d.add("Serial Number", d.str(0x20))
+	d.add("Asset Tag", d.str(0x21))
+	d.add("Part Number", d.str(0x22))
+	if !d.has(0x28) {
+		return
+	}
+	for index, key := range []string{"Core Count", "Core Enabled", "Thread Count"} {
+		count := int(d.byte(0x23 + index))
+		if count == 0xFF && d.has(0x2C+index*2) {
+			count = int(d.word(0x2A + index*2))
+		}
+		if count != 0 {
+			d.addf(key, "%d", count)
+		}
+	}
+	if characteristics := d.word(0x26); characteristics&0x00FC == 0 {
+		d.add("Characteristics", "None")
+	} else {
+		d.addList("Characteristics", "", bitNames(uint64(characteristics), 2, processorCharacteristics))
+	}
+}
+
+func formatMHz(speed uint16) string {
+	if speed == 0 {
+		return "Unknown"
+	}
+	return fmt.Sprintf("%d MHz", speed)
+}
+
+// x86处理器的ID, Signature和Flags
+func (d *decoder) processorID(family int) {
+	id := d.s.Data[0x08:0x10]
+	d.addf("ID", "%02X %02X %02X %02X %02X %02X %02X %02X", id[0], id[1], id[2], id[3], id[4], id[5], id[6], id[7])
+	eax := d.dword(0x08)
+	switch processorVendor(family, d.str(0x07)) {
+	case "Intel":
+		d.addf("Signature", "Type %d, Family %d, Model %d, Stepping %d",
+			(eax>>12)&0x3, ((eax>>20)&0xFF)+((eax>>8)&0x0F), ((eax>>12)&0xF0)+((eax>>4)&0x0F), eax&0xF)
+	case "AMD":
+		family, model := (eax>>8)&0xF, (eax>>4)&0xF
+		if family == 0xF {
+			family += (eax >> 20) & 0xFF
+			model |= (eax >> 12) & 0xF0
+		}
+		d.addf("Signature", "Family %d, Model %d, Stepping %d", family, model, eax&0xF)
+	default:
+		return
+	}
+	edx := d.dword(0x0C)
+	if edx&0xBFEFFBFF == 0 {
+		d.add("Flags", "None")
+		return
+	}
+	d.addList("Flags", "", bitNames(uint64(edx), 0, processorFlags))
+}
+
+func processorVendor(family int, manufacturer string) string {
+	switch {
+	case (family >= 0x0B && family <= 0x15) || (family >= 0x28 && family <= 0x2F) ||
+		(family >= 0xA1 && family <= 0xB3) || family == 0xB5 || (family >= 0xB9 && family <= 0xC7) ||
+		(family >= 0xCD && family <= 0xCF) || (family >= 0xD2 && family <= 0xDB) ||
+		(family >= 0xDD && family <= 0xE0):
+		return "Intel"
+	case (family >= 0x18 && family <= 0x1D) || family == 0x1F || (family >= 0x38 && family <= 0x3F) ||
+		(family >= 0x46 && family <= 0x4F) || (family >= 0x66 && family <= 0x6B) ||
+		(family >= 0x83 && family <= 0x8F) || family == 0xB6 || family == 0xB7 ||
+		(family >= 0xE4 && family <= 0xEF):
+		return "AMD"
+	case family == 0x01 || family == 0x02 || family == 0xFE:
+		if strings.HasPrefix(manufacturer, "Intel") || strings.HasPrefix(manufacturer, "GenuineIntel") {
+			return "Intel"
+		}
+		if strings.HasPrefix(manufacturer, "AMD") || strings.HasPrefix(manufacturer, "AuthenticAMD") {
+			return "AMD"
+		}
+	}
+	return ""
+}
+
+// type 7
+func (d *decoder) cache() {
+	if !d.has(0x0F) {
+		return
+	}
+	d.add("Socket Designation", d.str(0x04))
+	config := d.word(0x05)
+	enabled, socketed := "Disabled", "Not Socketed"
+	if config&0x0080 != 0 {
+		enabled = "Enabled"
+	}
+	if config&0x0008 != 0 {
+		socketed = "Socketed"
+	}
+	d.addf("Configuration", "%s, %s, Level %d", enabled, socketed, (config&0x0007)+1)
+	d.add("Operational Mode", []string{"Write Through", "Write Back", "Varies With Memory Address", "Unknown"}[(config>>8)&0x0003])
+	d.add("Location", []string{"Internal", "Reserved", "External", "Unknown"}[(config>>5)&0x0003])
+	if d.has(0x1B) {
+		d.add("Installed Size", formatCacheSize2(d.dword(0x17)))
+		d.add("Maximum Size", formatCacheSize2(d.dword(0x13)))
+	} else {
+		d.add("Installed Size", formatCacheSize(d.word(0x09)))
+		d.add("Maximum Size", formatCacheSize(d.word(0x07)))
+	}
+	if supported := d.word(0x0B); supported&0x007F == 0 {
+		d.add("Supported SRAM Types", "None")
+	} else {
+		d.addList("Supported SRAM Types", "", bitNames(uint64(supported), 0, cacheSRAMTypes))
+	}
+	if installed := bitNames(uint64(d.word(0x0D)), 0, cacheSRAMTypes); len(installed) == 0 {
+		d.add("Installed SRAM Type", "None")
+	} else {
+		d.add("Installed SRAM Type", installed[0])
+	}
+	if !d.has(0x13) {
+		return
+	}
+	if speed := d.byte(0x0F); speed == 0 {
+		d.add("Speed", "Unknown")
+	} else {
+		d.addf("Speed", "%d ns", speed)
+	}
+	d.add("Error Correction Type", lookup(cacheErrorCorrectionTypes, int(d.byte(0x10))))
+	d.add("System Type", lookup(cacheSystemTypes, int(d.byte(0x11))))
+	d.add("Associativity", lookup(cacheAssociativity, int(d.byte(0x12))))
+}
+
+func formatCacheSize(code uint16) string {
+	size := uint32(code & 0x7FFF)
+	if code&0x8000 != 0 {
+		size <<= 6
+	}
+	return fmt.Sprintf("%d kB", size)
+}
+
+func formatCacheSize2(code uint32) string {
+	size := uint64(code & 0x7FFFFFFF)
+	if code&0x80000000 != 0 {
+		size <<= 6
+	}
+	return fmt.Sprintf("%d kB", size)
+}
+
+// type 8
+func (d *decoder) connector() {
+	if !d.has(0x09) {
+		return
+	}
+	d.add("Internal Reference Designator", d.str(0x04))
+	d.add("Internal Connector Type", lookup(connectorTypes, int(d.byte(0x05))))
+	d.add("External Reference Designator", d.str(0x06))
+	d.add("External Connector Type", lookup(connectorTypes, int(d.byte(0x07))))
+	d.add("Port Type", lookup(portTypes, int(d.byte(0x08))))
+}
+
+// type 9
+func (d *decoder) slot() {
+	if !d.has(0x0C) {
+		return
+	}
+	slotType := int(d.byte(0x05))
+	d.add("Designation", d.str(0x04))
+	d.add("Type", slotBusWidths[int(d.byte(0x06))]+lookup(slotTypes, slotType))
+	d.add("Current Usage", lookup(slotUsages, int(d.byte(0x07))))
+	d.add("Length", lookup(slotLengths, int(d.byte(0x08))))
+	switch {
+	case slotType == 0x04 || slotType == 0x05 || slotType == 0x06 || (slotType >= 0x0E && slotType <= 0x13) ||
+		(slotType >= 0x1F && slotType <= 0x25) || (slotType >= 0xA5 && slotType <= 0xC6):
+		d.addf("ID", "%d", d.byte(0x09))
+	case slotType == 0x07:
+		d.addf("ID", "Adapter %d, Socket %d", d.byte(0x09), d.byte(0x0A))
+	}
+	code1 := d.byte(0x0B)
+	var code2 uint8
+	if d.has(0x0D) {
+		code2 = d.byte(0x0C)
+	}
+	switch {
+	case code1&0x01 != 0:
+		d.add("Characteristics", "Unknown")
+	case code1&0xFE == 0 && code2&0x07 == 0:
+		d.add("Characteristics", "None")
+	default:
+		characteristics := bitNames(uint64(code1), 1, slotCharacteristics1)
+		characteristics = append(characteristics, bitNames(uint64(code2), 0, slotCharacteristics2)...)
+		d.addList("Characteristics", "", characteristics)
+	}
+	if !d.has(0x11) {
+		return
+	}
+	d.busAddress(0x0D)
+}
+
+// 与dmidecode的dmi_slot_segment_bus_func一致, 全部为0xFF时表示没有总线地址
+func (d *decoder) busAddress(offset int) {
+	segment, bus, devfn := d.word(offset), d.byte(offset+2), d.byte(offset+3)
+	if segment != 0xFFFF || bus != 0xFF || devfn != 0xFF {
+		d.addf("Bus Address", "%04x:%02x:%02x.%x", segment, bus, devfn>>3, devfn&0x07)
+	}
+}
+
+// type 10, 每个设备2个字节, 有多个设备时每个设备一个记录, 名称为 "On Board Device N Information"
+func (d *decoder) onBoardDevices(header Record) []Record {
+	count := (len(d.s.Data) - 4) / 2
+	var result []Record
+	for index := 0; index < count; index++ {
+		record := header
+		if count > 1 {
+			record.Name = fmt.Sprintf("On Board Device %d Information", index+1)
+		}
+		code := d.byte(4 + 2*index)
+		d.fields = nil
+		d.add("Type", lookup(onboardDeviceTypes, int(code&0x7F)))
+		d.add("Status", enabledStatus(code))
+		d.add("Description", d.str(5+2*index))
+		record.Fields = d.fields
+		result = append(result, record)
+	}
+	return result
+}
+
+// 第7位表示是否启用
+func enabledStatus(code uint8) string {
+	if code&0x80 != 0 {
+		return "Enabled"
+	}
+	return "Disabled"
+}
+
+// type 11
+func (d *decoder) oemStrings() {
+	if !d.has(0x05) {
+		return
+	}
+	for index := 1; index <= int(d.byte(0x04)); index++ {
+		d.add(fmt.Sprintf("String %d", index), d.strIndex(uint8(index)))
+	}
+}
+
+// type 12
+func (d *decoder) configurationOptions() {
+	if !d.has(0x05) {
+		return
+	}
+	for index := 1; index <= int(d.byte(0x04)); index++ {
+		d.add(fmt.Sprintf("Option %d", index), d.strIndex(uint8(index)))
+	}
+}
+
+// type 13
+func (d *decoder) biosLanguage() {
+	if !d.has(0x16) {
+		return
+	}
+	if d.byte(0x05)&0x01 != 0 {
+		d.add("Language Description Format", "Abbreviated")
+	} else {
+		d.add("Language Description Format", "Long")
+	}
+	count := int(d.byte(0x04))
+	var languages []string
+	for index := 1; index <= count && index <= len(d.s.Strings); index++ {
+		languages = append(languages, d.s.Strings[index-1])
+	}
+	d.addList("Installable Languages", fmt.Sprintf("%d", count), languages)
+	d.add("Currently Installed Language", d.str(0x15))
+}
+
+// type 16
+func (d *decoder) memoryArray() {
+	if !d.has(0x0F) {
+		return
+	}
+	d.add("Location", lookup(memoryArrayLocations, int(d.byte(0x04))))
+	d.add("Use", lookup(memoryArrayUses, int(d.byte(0x05))))
+	d.add("Error Correction Type", lookup(memoryErrorCorrectionTypes, int(d.byte(0x06))))
+	if capacity := d.dword(0x07); capacity == 0x80000000 && d.has(0x17) {
+		d.add("Maximum Capacity", formatMemorySize(d.qword(0x0F)))
+	} else {
+		d.add("Maximum Capacity", formatMemorySize(uint64(capacity)<<10))
+	}
+	d.add("Error Information Handle", formatErrorHandle(d.word(0x0B)))
+	d.addf("Number Of Devices", "%d", d.word(0x0D))
+}
+
+func formatErrorHandle(handle uint16) string {
+	switch handle {
+	case 0xFFFE:
+		return "Not Provided"
+	case 0xFFFF:
+		return "No Error"
+	}
+	return fmt.Sprintf("0x%04X", handle)
+}
+
+// type 17
+func (d *decoder) memoryDevice() {
+	if !d.has(0x15) {
+		return
+	}
+	d.addf("Array Handle", "0x%04X", d.word(0x04))
+	d.add("Error Information Handle", formatErrorHandle(d.word(0x06)))
+	d.add("Total Width", formatWidth(d.word(0x08)))
+	d.add("Data Width", formatWidth(d.word(0x0A)))
+	switch size := d.word(0x0C); {
+	case size == 0:
+		d.add("Size", "No Module Installed")
+	case size == 0xFFFF:
+		d.add("Size", "Unknown")
+	case size == 0x7FFF && d.has(0x20):
+		extended := d.dword(0x1C) & 0x7FFFFFFF
+		switch {
+		case extended&0x3FF != 0:
+			d.addf("Size", "%d MB", extended)
+		case extended&0xFFC00 != 0:
+			d.addf("Size", "%d GB", extended>>10)
+		default:
+			d.addf("Size", "%d TB", extended>>20)
+		}
+	case size&0x8000 != 0:
+		d.addf("Size", "%d kB", size&0x7FFF)
+	default:
+		d.addf("Size", "%d MB", size)
+	}
+	d.add("Form Factor", lookup(memoryFormFactors, int(d.byte(0x0E))))
+	switch set := d.byte(0x0F); set {
+	case 0x00:
+		d.add("Set", "None")
+	case 0xFF:
+		d.add("Set", "Unknown")
+	default:
+		d.addf("Set", "%d", set)
+	}
+	d.add("Locator", d.str(0x10))
+	d.add("Bank Locator", d.str(0x11))
+	d.add("Type", lookup(memoryTypes, int(d.byte(0x12))))
+	if detail := d.word(0x13); detail&0xFFFE == 0 {
+		d.add("Type Detail", "None")
+	} else {
+		d.add("Type Detail", strings.Join(bitNames(uint64(detail), 1, memoryTypeDetails), " "))
+	}
+	if !d.has(0x17) {
+		return
+	}
+	d.add("Speed", formatMHz(d.word(0x15)))
+	if !d.has(0x1B) {
+		return
+	}
+	d.add("Manufacturer", d.str(0x17))
+	d.add("Serial Number", d.str(0x18))
+	d.add("Asset Tag", d.str(0x19))
+	d.add("Part Number", d.str(0x1A))
+	if !d.has(0x1C) {
+		return
+	}
+	if rank := d.byte(0x1B) & 0x0F; rank == 0 {
+		d.add("Rank", "Unknown")
+	} else {
+		d.addf("Rank", "%d", rank)
+	}
+	if !d.has(0x22) {
+		return
+	}
+	d.add("Configured Clock Speed", formatMHz(d.word(0x20)))
+	if !d.has(0x28) {
+		return
+	}
+	d.add("Minimum Voltage", formatMillivolts(d.word(0x22)))
+	d.add("Maximum Voltage", formatMillivolts(d.word(0x24)))
+	d.add("Configured Voltage", formatMillivolts(d.word(0x26)))
+}
+
+func formatWidth(width uint16) string {
+	if width == 0 || width == 0xFFFF {
+		return "Unknown"
+	}
+	return fmt.Sprintf("%d bits", width)
+}
+
+func formatMillivolts(code uint16) string {
+	if code == 0 {
+		return "Unknown"
+	}
+	if code%100 != 0 {
+		return fmt.Sprintf("%g V", float64(code)/1000)
+	}
+	return fmt.Sprintf("%.1f V", float64(code)/1000)
+}
+
+// type 22, SBDS的字段只在对应的通用字段没有设置时输出
+func (d *decoder) portableBattery() {
+	if !d.has(0x10) {
+		return
+	}
+	sbds := d.has(0x1A)
+	d.add("Location", d.str(0x04))
+	d.add("Manufacturer", d.str(0x05))
+	if d.byte(0x06) != 0 || !sbds {
+		d.add("Manufacture Date", d.str(0x06))
+	}
+	if d.byte(0x07) != 0 || !sbds {
+		d.add("Serial Number", d.str(0x07))
+	}
+	d.add("Name", d.str(0x08))
+	if d.byte(0x09) != 0x02 || !sbds {
+		d.add("Chemistry", lookup(batteryChemistries, int(d.byte(0x09))))
+	}
+	multiplier := batteryCapacityMultiplier(d.s)
+	if capacity := int(d.word(0x0A)); capacity == 0 {
+		d.add("Design Capacity", "Unknown")
+	} else {
+		d.addf("Design Capacity", "%d mWh", capacity*multiplier)
+	}
+	if voltage := d.word(0x0C); voltage == 0 {
+		d.add("Design Voltage", "Unknown")
+	} else {
+		d.addf("Design Voltage", "%d mV", voltage)
+	}
+	d.add("SBDS Version", d.str(0x0E))
+	if maximum := d.byte(0x0F); maximum == 0xFF {
+		d.add("Maximum Error", "Unknown")
+	} else {
+		d.addf("Maximum Error", "%d%%", maximum)
+	}
+	if !sbds {
+		return
+	}
+	if d.byte(0x07) == 0 {
+		d.addf("SBDS Serial Number", "%04X", d.word(0x10))
+	}
+	if d.byte(0x06) == 0 {
+		code := d.word(0x12)
+		if date := SBDSDate(code); !date.IsZero() {
+			d.add("SBDS Manufacture Date", date.Format("2006-01-02"))
+		} else {
+			// 与dmidecode一致, 月或日无效时照样输出
+			d.addf("SBDS Manufacture Date", "%d-%02d-%02d", 1980+int(code>>9), (code>>5)&0x0F, code&0x1F)
+		}
+	}
+	if d.byte(0x09) == 0x02 {
+		d.add("SBDS Chemistry", d.str(0x14))
+	}
+	d.addf("OEM-specific Information", "0x%08X", d.dword(0x16))
+}
+
+// type 26, 28, 29, 三种探针的结构相同, 只有单位和精度不同.
+// valueFormat和resolutionFormat对应测量值和分辨率, 原始值除以对应的divisor
+func (d *decoder) probe(locations map[int]string, valueFormat string, valueDivisor float64, resolutionFormat string, resolutionDivisor float64) {
+	if !d.has(0x14) {
+		return
+	}
+	value := func(key string, offset int, format string, divisor float64) {
+		if code := d.word(offset); code == 0x8000 {
+			d.add(key, "Unknown")
+		} else {
+			d.addf(key, format, float64(int16(code))/divisor)
+		}
+	}
+	d.add("Description", d.str(0x04))
+	d.add("Location", lookup(locations, int(d.byte(0x05)&0x1F)))
+	d.add("Status", lookup(probeStatus, int(d.byte(0x05)>>5)))
+	value("Maximum Value", 0x06, valueFormat, valueDivisor)
+	value("Minimum Value", 0x08, valueFormat, valueDivisor)
+	if code := d.word(0x0A); code == 0x8000 {
+		d.add("Resolution", "Unknown")
+	} else {
+		d.addf("Resolution", resolutionFormat, float64(code)/resolutionDivisor)
+	}
+	value("Tolerance", 0x0C, valueFormat, valueDivisor)
+	if code := d.word(0x0E); code == 0x8000 {
+		d.add("Accuracy", "Unknown")
+	} else {
+		d.addf("Accuracy", "%.2f%%", float64(code)/100)
+	}
+	d.addf("OEM-specific Information", "0x%08X", d.dword(0x10))
+	if !d.has(0x16) {
+		return
+	}
+	value("Nominal Value", 0x14, valueFormat, valueDivisor)
+}
+
+// type 27
+func (d *decoder) coolingDevice() {
+	if !d.has(0x0C) {
+		return
+	}
+	if handle := d.word(0x04); handle != 0xFFFF {
+		d.addf("Temperature Probe Handle", "0x%04X", handle)
+	}
+	d.add("Type", lookup(coolingDeviceTypes, int(d.byte(0x06)&0x1F)))
+	d.add("Status", lookup(probeStatus, int(d.byte(0x06)>>5)))
+	if group := d.byte(0x07); group != 0 {
+		d.addf("Cooling Unit Group", "%d", group)
+	}
+	d.addf("OEM-specific Information", "0x%08X", d.dword(0x08))
+	if !d.has(0x0E) {
+		return
+	}
+	if speed := d.word(0x0C); speed == 0x8000 {
+		d.add("Nominal Speed", "Unknown Or Non-rotating")
+	} else {
+		d.addf("Nominal Speed", "%d rpm", speed)
+	}
+	if !d.has(0x0F) {
+		return
+	}
+	d.add("Description", d.str(0x0E))
+}
+
+// type 41
+func (d *decoder) onboardDevice() {
+	if !d.has(0x0B) {
+		return
+	}
+	d.add("Reference Designation", d.str(0x04))
+	d.add("Type", lookup(onboardDeviceTypes, int(d.byte(0x05)&0x7F)))
+	d.add("Status", enabledStatus(d.byte(0x05)))
+	d.addf("Type Instance", "%d", d.byte(0x06))
+	d.busAddress(0x07)
+}
@@ -0,0 +1,1082 @@
+package dmidecode
+
+// SMBIOS枚举值对应的名称, 与dmidecode的输出一致
+
+// 记录名
+var structureNames = map[int]string{
+	0:   "BIOS Information",
+	1:   "System Information",
+	2:   "Base Board Information",
+	3:   "Chassis Information",
+	4:   "Processor Information",
+	5:   "Memory Controller Information",
+	6:   "Memory Module Information",
+	7:   "Cache Information",
+	8:   "Port Connector Information",
+	9:   "System Slot Information",
+	10:  "On Board Device Information",
+	11:  "OEM Strings",
+	12:  "System Configuration Options",
+	13:  "BIOS Language Information",
+	14:  "Group Associations",
+	15:  "System Event Log",
+	16:  "Physical Memory Array",
+	17:  "Memory Device",
+	18:  "32-bit Memory Error Information",
+	19:  "Memory Array Mapped Address",
+	20:  "Memory Device Mapped Address",
+	21:  "Built-in Pointing Device",
+	22:  "Portable Battery",
+	23:  "System Reset",
+	24:  "Hardware Security",
+	25:  "System Power Controls",
+	26:  "Voltage Probe",
+	27:  "Cooling Device",
+	28:  "Temperature Probe",
+	29:  "Electrical Current Probe",
+	30:  "Out-of-band Remote Access",
+	31:  "Boot Integrity Services Entry Point",
+	32:  "System Boot Information",
+	33:  "64-bit Memory Error Information",
+	34:  "Management Device",
+	35:  "Management Device Component",
+	36:  "Management Device Threshold Data",
+	37:  "Memory Channel",
+	38:  "IPMI Device Information",
+	39:  "System Power Supply",
+	40:  "Additional Information",
+	41:  "Onboard Device",
+	42:  "Management Controller Host Interface",
+	43:  "TPM Device",
+	44:  "Processor Additional Information",
+	126: "Inactive",
+	127: "End Of Table",
+}
+
+// Contained Elements中使用的短名称
+var structureTypeNames = map[int]string{
+	0:  "BIOS",
+	1:  "System",
+	2:  "Base Board",
+	3:  "Chassis",
+	4:  "Processor",
+	5:  "Memory Controller",
+	6:  "Memory Module",
+	7:  "Cache",
+	8:  "Port Connector",
+	9:  "System Slots",
+	10: "On Board Devices",
+	11: "OEM Strings",
+	12: "System Configuration Options",
+	13: "BIOS Language",
+	14: "Group Associations",
+	15: "System Event Log",
+	16: "Physical Memory Array",
+	17: "Memory Device",
+	18: "32-bit Memory Error",
+	19: "Memory Array Mapped Address",
+	20: "Memory Device Mapped Address",
+	21: "Built-in Pointing Device",
+	22: "Portable Battery",
+	23: "System Reset",
+	24: "Hardware Security",
+	25: "System Power Controls",
+	26: "Voltage Probe",
+	27: "Cooling Device",
+	28: "Temperature Probe",
+	29: "Electrical Current Probe",
+	30: "Out-of-band Remote Access",
+	31: "Boot Integrity Services",
+	32: "System Boot",
+	33: "64-bit Memory Error",
+	34: "Management Device",
+	35: "Management Device Component",
+	36: "Management Device Threshold Data",
+	37: "Memory Channel",
+	38: "IPMI Device",
+	39: "Power Supply",
+	40: "Additional Information",
+	41: "Onboard Device",
+	42: "Management Controller Host Interface",
+	43: "TPM Device",
+	44: "Processor Additional Information",
+}
+
+// type 0, 第4到31位
+var biosCharacteristics = []string{
+	"ISA is supported",
+	"MCA is supported",
+	"EISA is supported",
+	"PCI is supported",
+	"PC Card (PCMCIA) is supported",
+	"PNP is supported",
+	"APM is supported",
+	"BIOS is upgradeable",
+	"BIOS shadowing is allowed",
+	"VLB is supported",
+	"ESCD support is available",
+	"Boot from CD is supported",
+	"Selectable boot is supported",
+	"BIOS ROM is socketed",
+	"Boot from PC Card (PCMCIA) is supported",
+	"EDD is supported",
+	"Japanese floppy for NEC 9800 1.2 MB is supported (int 13h)",
+	"Japanese floppy for Toshiba 1.2 MB is supported (int 13h)",
+	"5.25\"/360 kB floppy services are supported (int 13h)",
+	"5.25\"/1.2 MB floppy services are supported (int 13h)",
+	"3.5\"/720 kB floppy services are supported (int 13h)",
+	"3.5\"/2.88 MB floppy services are supported (int 13h)",
+	"Print screen service is supported (int 5h)",
+	"8042 keyboard services are supported (int 9h)",
+	"Serial services are supported (int 14h)",
+	"Printer services are supported (int 17h)",
+	"CGA/mono video services are supported (int 10h)",
+	"NEC PC-98",
+}
+
+var biosCharacteristicsExt1 = []string{
+	"ACPI is supported",
+	"USB legacy is supported",
+	"AGP is supported",
+	"I2O boot is supported",
+	"LS-120 boot is supported",
+	"ATAPI Zip drive boot is supported",
+	"IEEE 1394 boot is supported",
+	"Smart battery is supported",
+}
+
+var biosCharacteristicsExt2 = []string{
+	"BIOS boot specification is supported",
+	"Function key-initiated network boot is supported",
+	"Targeted content distribution is supported",
+	"UEFI is supported",
+	"System is a virtual machine",
+}
+
+// type 1
+var wakeUpTypes = map[int]string{
+	0: "Reserved",
+	1: "Other",
+	2: "Unknown",
+	3: "APM Timer",
+	4: "Modem Ring",
+	5: "LAN Remote",
+	6: "Power Switch",
+	7: "PCI PME#",
+	8: "AC Power Restored",
+}
+
+// type 2
+var baseBoardFeatures = []string{
+	"Board is a hosting board",
+	"Board requires at least one daughter board",
+	"Board is removable",
+	"Board is replaceable",
+	"Board is hot swappable",
+}
+
+var baseBoardTypes = map[int]string{
+	1:  "Unknown",
+	2:  "Other",
+	3:  "Server Blade",
+	4:  "Connectivity Switch",
+	5:  "System Management Module",
+	6:  "Processor Module",
+	7:  "I/O Module",
+	8:  "Memory Module",
+	9:  "Daughter Board",
+	10: "Motherboard",
+	11: "Processor+Memory Module",
+	12: "Processor+I/O Module",
+	13: "Interconnect Board",
+}
+
+// type 3
+var chassisTypes = map[int]string{
+	1:  "Other",
+	2:  "Unknown",
+	3:  "Desktop",
+	4:  "Low Profile Desktop",
+	5:  "Pizza Box",
+	6:  "Mini Tower",
+	7:  "Tower",
+	8:  "Portable",
+	9:  "Laptop",
+	10: "Notebook",
+	11: "Hand Held",
+	12: "Docking Station",
+	13: "All In One",
+	14: "Sub Notebook",
+	15: "Space-saving",
+	16: "Lunch Box",
+	17: "Main Server Chassis",
+	18: "Expansion Chassis",
+	19: "Sub Chassis",
+	20: "Bus Expansion Chassis",
+	21: "Peripheral Chassis",
+	22: "RAID Chassis",
+	23: "Rack Mount Chassis",
+	24: "Sealed-case PC",
+	25: "Multi-system",
+	26: "CompactPCI",
+	27: "AdvancedTCA",
+	28: "Blade",
+	29: "Blade Enclosing",
+	30: "Tablet",
+	31: "Convertible",
+	32: "Detachable",
+	33: "IoT Gateway",
+	34: "Embedded PC",
+	35: "Mini PC",
+	36: "Stick PC",
+}
+
+var chassisStates = map[int]string{
+	1: "Other",
+	2: "Unknown",
+	3: "Safe",
+	4: "Warning",
+	5: "Critical",
+	6: "Non-recoverable",
+}
+
+var chassisSecurityStatus = map[int]string{
+	1: "Other",
+	2: "Unknown",
+	3: "None",
+	4: "External Interface Locked Out",
+	5: "External Interface Enabled",
+}
+
+// type 4
+var processorTypes = map[int]string{
+	1: "Other",
+	2: "Unknown",
+	3: "Central Processor",
+	4: "Math Processor",
+	5: "DSP Processor",
+	6: "Video Processor",
+}
+
+var processorFamilies = map[int]string{
+	0x01: "Other",
+	0x02: "Unknown",
+	0x03: "8086",
+	0x04: "80286",
+	0x05: "80386",
+	0x06: "80486",
+	0x07: "8087",
+	0x08: "80287",
+	0x09: "80387",
+	0x0A: "80487",
+	0x0B: "Pentium",
+	0x0C: "Pentium Pro",
+	0x0D: "Pentium II",
+	0x0E: "Pentium MMX",
+	0x0F: "Celeron",
+	0x10: "Pentium II Xeon",
+	0x11: "Pentium III",
+	0x12: "M1",
+	0x13: "M2",
+	0x14: "Celeron M",
+	0x15: "Pentium 4 HT",
+	0x18: "Duron",
+	0x19: "K5",
+	0x1A: "K6",
+	0x1B: "K6-2",
+	0x1C: "K6-3",
+	0x1D: "Athlon",
+	0x1E: "AMD29000",
+	0x1F: "K6-2+",
+	0x20: "Power PC",
+	0x21: "Power PC 601",
+	0x22: "Power PC 603",
+	0x23: "Power PC 603+",
+	0x24: "Power PC 604",
+	0x25: "Power PC 620",
+	0x26: "Power PC x704",
+	0x27: "Power PC 750",
+	0x28: "Core Duo",
+	0x29: "Core Duo Mobile",
+	0x2A: "Core Solo Mobile",
+	0x2B: "Atom",
+	0x2C: "Core M",
+	0x2D: "Core m3",
+	0x2E: "Core m5",
+	0x2F: "Core m7",
+	0x30: "Alpha",
+	0x31: "Alpha 21064",
+	0x32: "Alpha 21066",
+	0x33: "Alpha 21164",
+	0x34: "Alpha 21164PC",
+	0x35: "Alpha 21164a",
+	0x36: "Alpha 21264",
+	0x37: "Alpha 21364",
+	0x38: "Turion II Ultra Dual-Core Mobile M",
+	0x39: "Turion II Dual-Core Mobile M",
+	0x3A: "Athlon II Dual-Core M",
+	0x3B: "Opteron 6100",
+	0x3C: "Opteron 4100",
+	0x3D: "Opteron 6200",
+	0x3E: "Opteron 4200",
+	0x3F: "FX",
+	0x40: "MIPS",
+	0x41: "MIPS R4000",
+	0x42: "MIPS R4200",
+	0x43: "MIPS R4400",
+	0x44: "MIPS R4600",
+	0x45: "MIPS R10000",
+	0x46: "C-Series",
+	0x47: "E-Series",
+	0x48: "A-Series",
+	0x49: "G-Series",
+	0x4A: "Z-Series",
+	0x4B: "R-Series",
+	0x4C: "Opteron 4300",
+	0x4D: "Opteron 6300",
+	0x4E: "Opteron 3300",
+	0x4F: "FirePro",
+	0x50: "SPARC",
+	0x51: "SuperSPARC",
+	0x52: "MicroSPARC II",
+	0x53: "MicroSPARC IIep",
+	0x54: "UltraSPARC",
+	0x55: "UltraSPARC II",
+	0x56: "UltraSPARC IIi",
+	0x57: "UltraSPARC III",
+	0x58: "UltraSPARC IIIi",
+	0x60: "68040",
+	0x61: "68xxx",
+	0x62: "68000",
+	0x63: "68010",
+	0x64: "68020",
+	0x65: "68030",
+	0x66: "Athlon X4",
+	0x67: "Opteron X1000",
+	0x68: "Opteron X2000",
+	0x69: "Opteron A-Series",
+	0x6A: "Opteron X3000",
+	0x6B: "Zen",
+	0x70: "Hobbit",
+	0x78: "Crusoe TM5000",
+	0x79: "Crusoe TM3000",
+	0x7A: "Efficeon TM8000",
+	0x80: "Weitek",
+	0x82: "Itanium",
+	0x83: "Athlon 64",
+	0x84: "Opteron",
+	0x85: "Sempron",
+	0x86: "Turion 64",
+	0x87: "Dual-Core Opteron",
+	0x88: "Athlon 64 X2",
+	0x89: "Turion 64 X2",
+	0x8A: "Quad-Core Opteron",
+	0x8B: "Third-Generation Opteron",
+	0x8C: "Phenom FX",
+	0x8D: "Phenom X4",
+	0x8E: "Phenom X2",
+	0x8F: "Athlon X2",
+	0x90: "PA-RISC",
+	0x91: "PA-RISC 8500",
+	0x92: "PA-RISC 8000",
+	0x93: "PA-RISC 7300LC",
+	0x94: "PA-RISC 7200",
+	0x95: "PA-RISC 7100LC",
+	0x96: "PA-RISC 7100",
+	0xA0: "V30",
+	0xA1: "Quad-Core Xeon 3200",
+	0xA2: "Dual-Core Xeon 3000",
+	0xA3: "Quad-Core Xeon 5300",
+	0xA4: "Dual-Core Xeon 5100",
+	0xA5: "Dual-Core Xeon 5000",
+	0xA6: "Dual-Core Xeon LV",
+	0xA7: "Dual-Core Xeon ULV",
+	0xA8: "Dual-Core Xeon 7100",
+	0xA9: "Quad-Core Xeon 5400",
+	0xAA: "Quad-Core Xeon",
+	0xAB: "Dual-Core Xeon 5200",
+	0xAC: "Dual-Core Xeon 7200",
+	0xAD: "Quad-Core Xeon 7300",
+	0xAE: "Quad-Core Xeon 7400",
+	0xAF: "Multi-Core Xeon 7400",
+	0xB0: "Pentium III Xeon",
+	0xB1: "Pentium III Speedstep",
+	0xB2: "Pentium 4",
+	0xB3: "Xeon",
+	0xB4: "AS400",
+	0xB5: "Xeon MP",
+	0xB6: "Athlon XP",
+	0xB7: "Athlon MP",
+	0xB8: "Itanium 2",
+	0xB9: "Pentium M",
+	0xBA: "Celeron D",
+	0xBB: "Pentium D",
+	0xBC: "Pentium EE",
+	0xBD: "Core Solo",
+	0xBF: "Core 2 Duo",
+	0xC0: "Core 2 Solo",
+	0xC1: "Core 2 Extreme",
+	0xC2: "Core 2 Quad",
+	0xC3: "Core 2 Extreme Mobile",
+	0xC4: "Core 2 Duo Mobile",
+	0xC5: "Core 2 Solo Mobile",
+	0xC6: "Core i7",
+	0xC7: "Dual-Core Celeron",
+	0xC8: "IBM390",
+	0xC9: "G4",
+	0xCA: "G5",
+	0xCB: "ESA/390 G6",
+	0xCC: "z/Architecture",
+	0xCD: "Core i5",
+	0xCE: "Core i3",
+	0xCF: "Core i9",
+	0xD2: "C7-M",
+	0xD3: "C7-D",
+	0xD4: "C7",
+	0xD5: "Eden",
+	0xD6: "Multi-Core Xeon",
+	0xD7: "Dual-Core Xeon 3xxx",
+	0xD8: "Quad-Core Xeon 3xxx",
+	0xD9: "Nano",
+	0xDA: "Dual-Core Xeon 5xxx",
+	0xDB: "Quad-Core Xeon 5xxx",
+	0xDD: "Dual-Core Xeon 7xxx",
+	0xDE: "Quad-Core Xeon 7xxx",
+	0xDF: "Multi-Core Xeon 7xxx",
+	0xE0: "Multi-Core Xeon 3400",
+	0xE4: "Opteron 3000",
+	0xE5: "Sempron II",
+	0xE6: "Embedded Opteron Quad-Core",
+	0xE7: "Phenom Triple-Core",
+	0xE8: "Turion Ultra Dual-Core Mobile",
+	0xE9: "Turion Dual-Core Mobile",
+	0xEA: "Athlon Dual-Core",
+	0xEB: "Sempron SI",
+	0xEC: "Phenom II",
+	0xED: "Athlon II",
+	0xEE: "Six-Core Opteron",
+	0xEF: "Sempron M",
+	0xFA: "i860",
+	0xFB: "i960",
+
+	0x100: "ARMv7",
+	0x101: "ARMv8",
+	0x102: "ARMv9",
+	0x104: "SH-3",
+	0x105: "SH-4",
+	0x118: "ARM",
+	0x119: "StrongARM",
+	0x12C: "6x86",
+	0x12D: "MediaGX",
+	0x12E: "MII",
+	0x140: "WinChip",
+	0x15E: "DSP",
+	0x1F4: "Video Processor",
+	0x200: "RV32",
+	0x201: "RV64",
+	0x202: "RV128",
+	0x258: "LoongArch",
+}
+
+var processorFlags = []string{
+	"FPU (Floating-point unit on-chip)",
+	"VME (Virtual mode extension)",
+	"DE (Debugging extension)",
+	"PSE (Page size extension)",
+	"TSC (Time stamp counter)",
+	"MSR (Model specific registers)",
+	"PAE (Physical address extension)",
+	"MCE (Machine check exception)",
+	"CX8 (CMPXCHG8 instruction supported)",
+	"APIC (On-chip APIC hardware supported)",
+	"",
+	"SEP (Fast system call)",
+	"MTRR (Memory type range registers)",
+	"PGE (Page global enable)",
+	"MCA (Machine check architecture)",
+	"CMOV (Conditional move instruction supported)",
+	"PAT (Page attribute table)",
+	"PSE-36 (36-bit page size extension)",
+	"PSN (Processor serial number present and enabled)",
+	"CLFSH (CLFLUSH instruction supported)",
+	"",
+	"DS (Debug store)",
+	"ACPI (ACPI supported)",
+	"MMX (MMX technology supported)",
+	"FXSR (FXSAVE and FXSTOR instructions supported)",
+	"SSE (Streaming SIMD extensions)",
+	"SSE2 (Streaming SIMD extensions 2)",
+	"SS (Self-snoop)",
+	"HTT (Multi-threading)",
+	"TM (Thermal monitor supported)",
+	"",
+	"PBE (Pending break enabled)",
+}
+
+var processorStatus = map[int]string{
+	0: "Unknown",
+	1: "Enabled",
+	2: "Disabled By User",
+	3: "Disabled By BIOS",
+	4: "Idle",
+	7: "Other",
+}
+
+var processorUpgrades = map[int]string{
+	0x01: "Other",
+	0x02: "Unknown",
+	0x03: "Daughter Board",
+	0x04: "ZIF Socket",
+	0x05: "Replaceable Piggy Back",
+	0x06: "None",
+	0x07: "LIF Socket",
+	0x08: "Slot 1",
+	0x09: "Slot 2",
+	0x0A: "370-pin Socket",
+	0x0B: "Slot A",
+	0x0C: "Slot M",
+	0x0D: "Socket 423",
+	0x0E: "Socket A (Socket 462)",
+	0x0F: "Socket 478",
+	0x10: "Socket 754",
+	0x11: "Socket 940",
+	0x12: "Socket 939",
+	0x13: "Socket mPGA604",
+	0x14: "Socket LGA771",
+	0x15: "Socket LGA775",
+	0x16: "Socket S1",
+	0x17: "Socket AM2",
+	0x18: "Socket F (1207)",
+	0x19: "Socket LGA1366",
+	0x1A: "Socket G34",
+	0x1B: "Socket AM3",
+	0x1C: "Socket C32",
+	0x1D: "Socket LGA1156",
+	0x1E: "Socket LGA1567",
+	0x1F: "Socket PGA988A",
+	0x20: "Socket BGA1288",
+	0x21: "Socket rPGA988B",
+	0x22: "Socket BGA1023",
+	0x23: "Socket BGA1224",
+	0x24: "Socket LGA1155",
+	0x25: "Socket LGA1356",
+	0x26: "Socket LGA2011",
+	0x27: "Socket FS1",
+	0x28: "Socket FS2",
+	0x29: "Socket FM1",
+	0x2A: "Socket FM2",
+	0x2B: "Socket LGA2011-3",
+	0x2C: "Socket LGA1356-3",
+	0x2D: "Socket LGA1150",
+	0x2E: "Socket BGA1168",
+	0x2F: "Socket BGA1234",
+	0x30: "Socket BGA1364",
+	0x31: "Socket AM4",
+	0x32: "Socket LGA1151",
+	0x33: "Socket BGA1356",
+	0x34: "Socket BGA1440",
+	0x35: "Socket BGA1515",
+	0x36: "Socket LGA3647-1",
+	0x37: "Socket SP3",
+	0x38: "Socket SP3r2",
+	0x39: "Socket LGA2066",
+	0x3A: "Socket BGA1392",
+	0x3B: "Socket BGA1510",
+	0x3C: "Socket BGA1528",
+	0x3D: "Socket LGA4189",
+	0x3E: "Socket LGA1200",
+	0x3F: "Socket LGA4677",
+	0x40: "Socket LGA1700",
+}
+
+// 第2位开始
+var processorCharacteristics = []string{
+	"64-bit capable",
+	"Multi-Core",
+	"Hardware Thread",
+	"Execute Protection",
+	"Enhanced Virtualization",
+	"Power/Performance Control",
+	"128-bit Capable",
+	"Arm64 SoC ID",
+}
+
+// type 7
+var cacheSRAMTypes = []string{
+	"Other",
+	"Unknown",
+	"Non-burst",
+	"Burst",
+	"Pipeline Burst",
+	"Synchronous",
+	"Asynchronous",
+}
+
+var cacheErrorCorrectionTypes = map[int]string{
+	1: "Other",
+	2: "Unknown",
+	3: "None",
+	4: "Parity",
+	5: "Single-bit ECC",
+	6: "Multi-bit ECC",
+}
+
+var cacheSystemTypes = map[int]string{
+	1: "Other",
+	2: "Unknown",
+	3: "Instruction",
+	4: "Data",
+	5: "Unified",
+}
+
+var cacheAssociativity = map[int]string{
+	1:  "Other",
+	2:  "Unknown",
+	3:  "Direct Mapped",
+	4:  "2-way Set-associative",
+	5:  "4-way Set-associative",
+	6:  "Fully Associative",
+	7:  "8-way Set-associative",
+	8:  "16-way Set-associative",
+	9:  "12-way Set-associative",
+	10: "24-way Set-associative",
+	11: "32-way Set-associative",
+	12: "48-way Set-associative",
+	13: "64-way Set-associative",
+	14: "20-way Set-associative",
+}
+
+// type 8
+var connectorTypes = map[int]string{
+	0x00: "None",
+	0x01: "Centronics",
+	0x02: "Mini Centronics",
+	0x03: "Proprietary",
+	0x04: "DB-25 male",
+	0x05: "DB-25 female",
+	0x06: "DB-15 male",
+	0x07: "DB-15 female",
+	0x08: "DB-9 male",
+	0x09: "DB-9 female",
+	0x0A: "RJ-11",
+	0x0B: "RJ-45",
+	0x0C: "50 Pin MiniSCSI",
+	0x0D: "Mini DIN",
+	0x0E: "Micro DIN",
+	0x0F: "PS/2",
+	0x10: "Infrared",
+	0x11: "HP-HIL",
+	0x12: "Access Bus (USB)",
+	0x13: "SSA SCSI",
+	0x14: "Circular DIN-8 male",
+	0x15: "Circular DIN-8 female",
+	0x16: "On Board IDE",
+	0x17: "On Board Floppy",
+	0x18: "9 Pin Dual Inline (pin 10 cut)",
+	0x19: "25 Pin Dual Inline (pin 26 cut)",
+	0x1A: "50 Pin Dual Inline",
+	0x1B: "68 Pin Dual Inline",
+	0x1C: "On Board Sound Input From CD-ROM",
+	0x1D: "Mini Centronics Type-14",
+	0x1E: "Mini Centronics Type-26",
+	0x1F: "Mini Jack (headphones)",
+	0x20: "BNC",
+	0x21: "IEEE 1394",
+	0x22: "SAS/SATA Plug Receptacle",
+	0x23: "USB Type-C Receptacle",
+	0xA0: "PC-98",
+	0xA1: "PC-98 Hireso",
+	0xA2: "PC-H98",
+	0xA3: "PC-98 Note",
+	0xA4: "PC-98 Full",
+	0xFF: "Other",
+}
+
+var portTypes = map[int]string{
+	0x00: "None",
+	0x01: "Parallel Port XT/AT Compatible",
+	0x02: "Parallel Port PS/2",
+	0x03: "Parallel Port ECP",
+	0x04: "Parallel Port EPP",
+	0x05: "Parallel Port ECP/EPP",
+	0x06: "Serial Port XT/AT Compatible",
+	0x07: "Serial Port 16450 Compatible",
+	0x08: "Serial Port 16550 Compatible",
+	0x09: "Serial Port 16550A Compatible",
+	0x0A: "SCSI Port",
+	0x0B: "MIDI Port",
+	0x0C: "Joystick Port",
+	0x0D: "Keyboard Port",
+	0x0E: "Mouse Port",
+	0x0F: "SSA SCSI",
+	0x10: "USB",
+	0x11: "Firewire (IEEE P1394)",
+	0x12: "PCMCIA Type I",
+	0x13: "PCMCIA Type II",
+	0x14: "PCMCIA Type III",
+	0x15: "Cardbus",
+	0x16: "Access Bus Port",
+	0x17: "SCSI II",
+	0x18: "SCSI Wide",
+	0x19: "PC-98",
+	0x1A: "PC-98 Hireso",
+	0x1B: "PC-H98",
+	0x1C: "Video Port",
+	0x1D: "Audio Port",
+	0x1E: "Modem Port",
+	0x1F: "Network Port",
+	0x20: "SATA",
+	0x21: "SAS",
+	0x22: "MFDP (Multi-Function Display Port)",
+	0x23: "Thunderbolt",
+	0xA0: "8251 Compatible",
+	0xA1: "8251 FIFO Compatible",
+	0xFF: "Other",
+}
+
+// type 9
+var slotTypes = map[int]string{
+	0x01: "Other",
+	0x02: "Unknown",
+	0x03: "ISA",
+	0x04: "MCA",
+	0x05: "EISA",
+	0x06: "PCI",
+	0x07: "PC Card (PCMCIA)",
+	0x08: "VLB",
+	0x09: "Proprietary",
+	0x0A: "Processor Card",
+	0x0B: "Proprietary Memory Card",
+	0x0C: "I/O Riser Card",
+	0x0D: "NuBus",
+	0x0E: "PCI-66",
+	0x0F: "AGP",
+	0x10: "AGP 2x",
+	0x11: "AGP 4x",
+	0x12: "PCI-X",
+	0x13: "AGP 8x",
+	0x14: "M.2 Socket 1-DP",
+	0x15: "M.2 Socket 1-SD",
+	0x16: "M.2 Socket 2",
+	0x17: "M.2 Socket 3",
+	0x18: "MXM Type I",
+	0x19: "MXM Type II",
+	0x1A: "MXM Type III",
+	0x1B: "MXM Type III-HE",
+	0x1C: "MXM Type IV",
+	0x1D: "MXM 3.0 Type A",
+	0x1E: "MXM 3.0 Type B",
+	0x1F: "PCI Express 2 SFF-8639 (U.2)",
+	0x20: "PCI Express 3 SFF-8639 (U.2)",
+	0x21: "PCI Express Mini 52-pin with bottom-side keep-outs",
+	0x22: "PCI Express Mini 52-pin without bottom-side keep-outs",
+	0x23: "PCI Express Mini 76-pin",
+	0x24: "PCI Express 4 SFF-8639 (U.2)",
+	0x25: "PCI Express 5 SFF-8639 (U.2)",
+	0x26: "OCP NIC 3.0 Small Form Factor (SFF)",
+	0x27: "OCP NIC 3.0 Large Form Factor (LFF)",
+	0x28: "OCP NIC Prior to 3.0",
+	0x30: "CXL FLexbus 1.0",
+	0xA0: "PC-98/C20",
+	0xA1: "PC-98/C24",
+	0xA2: "PC-98/E",
+	0xA3: "PC-98/Local Bus",
+	0xA4: "PC-98/Card",
+	0xA5: "PCI Express",
+	0xA6: "PCI Express x1",
+	0xA7: "PCI Express x2",
+	0xA8: "PCI Express x4",
+	0xA9: "PCI Express x8",
+	0xAA: "PCI Express x16",
+	0xAB: "PCI Express 2",
+	0xAC: "PCI Express 2 x1",
+	0xAD: "PCI Express 2 x2",
+	0xAE: "PCI Express 2 x4",
+	0xAF: "PCI Express 2 x8",
+	0xB0: "PCI Express 2 x16",
+	0xB1: "PCI Express 3",
+	0xB2: "PCI Express 3 x1",
+	0xB3: "PCI Express 3 x2",
+	0xB4: "PCI Express 3 x4",
+	0xB5: "PCI Express 3 x8",
+	0xB6: "PCI Express 3 x16",
+	0xB8: "PCI Express 4",
+	0xB9: "PCI Express 4 x1",
+	0xBA: "PCI Express 4 x2",
+	0xBB: "PCI Express 4 x4",
+	0xBC: "PCI Express 4 x8",
+	0xBD: "PCI Express 4 x16",
+	0xBE: "PCI Express 5",
+	0xBF: "PCI Express 5 x1",
+	0xC0: "PCI Express 5 x2",
+	0xC1: "PCI Express 5 x4",
+	0xC2: "PCI Express 5 x8",
+	0xC3: "PCI Express 5 x16",
+	0xC4: "PCI Express 6+",
+	0xC5: "EDSFF E1",
+	0xC6: "EDSFF E3",
+}
+
+// 拼在slot类型前面, 例如 "x1 PCI Express"
+var slotBusWidths = map[int]string{
+	0x03: "8-bit ",
+	0x04: "16-bit ",
+	0x05: "32-bit ",
+	0x06: "64-bit ",
+	0x07: "128-bit ",
+	0x08: "x1 ",
+	0x09: "x2 ",
+	0x0A: "x4 ",
+	0x0B: "x8 ",
+	0x0C: "x12 ",
+	0x0D: "x16 ",
+	0x0E: "x32 ",
+}
+
+var slotUsages = map[int]string{
+	1: "Other",
+	2: "Unknown",
+	3: "Available",
+	4: "In Use",
+	5: "Unavailable",
+}
+
+var slotLengths = map[int]string{
+	1: "Other",
+	2: "Unknown",
+	3: "Short",
+	4: "Long",
+	5: "2.5\" drive form factor",
+	6: "3.5\" drive form factor",
+}
+
+// 第一个字节从第1位开始
+var slotCharacteristics1 = []string{
+	"5.0 V is provided",
+	"3.3 V is provided",
+	"Opening is shared",
+	"PC Card-16 is supported",
+	"Cardbus is supported",
+	"Zoom Video is supported",
+	"Modem ring resume is supported",
+}
+
+var slotCharacteristics2 = []string{
+	"PME signal is supported",
+	"Hot-plug devices are supported",
+	"SMBus signal is supported",
+	"PCIe slot bifurcation is supported",
+	"Async/surprise removal is supported",
+	"Flexbus slot, CXL 1.0 capable",
+	"Flexbus slot, CXL 2.0 capable",
+	"Flexbus slot, CXL 3.0 capable",
+}
+
+// type 10, 41, 第0到6位, 第7位为是否启用
+var onboardDeviceTypes = map[int]string{
+	0x01: "Other",
+	0x02: "Unknown",
+	0x03: "Video",
+	0x04: "SCSI Controller",
+	0x05: "Ethernet",
+	0x06: "Token Ring",
+	0x07: "Sound",
+	0x08: "PATA Controller",
+	0x09: "SATA Controller",
+	0x0A: "SAS Controller",
+	0x0B: "Wireless LAN",
+	0x0C: "Bluetooth",
+	0x0D: "WWAN",
+	0x0E: "eMMC",
+	0x0F: "NVMe Controller",
+	0x10: "UFS Controller",
+}
+
+// type 16
+var memoryArrayLocations = map[int]string{
+	0x01: "Other",
+	0x02: "Unknown",
+	0x03: "System Board Or Motherboard",
+	0x04: "ISA Add-on Card",
+	0x05: "EISA Add-on Card",
+	0x06: "PCI Add-on Card",
+	0x07: "MCA Add-on Card",
+	0x08: "PCMCIA Add-on Card",
+	0x09: "Proprietary Add-on Card",
+	0x0A: "NuBus",
+	0xA0: "PC-98/C20 Add-on Card",
+	0xA1: "PC-98/C24 Add-on Card",
+	0xA2: "PC-98/E Add-on Card",
+	0xA3: "PC-98/Local Bus Add-on Card",
+	0xA4: "CXL Add-on Card",
+}
+
+var memoryArrayUses = map[int]string{
+	1: "Other",
+	2: "Unknown",
+	3: "System Memory",
+	4: "Video Memory",
+	5: "Flash Memory",
+	6: "Non-volatile RAM",
+	7: "Cache Memory",
+}
+
+var memoryErrorCorrectionTypes = map[int]string{
+	1: "Other",
+	2: "Unknown",
+	3: "None",
+	4: "Parity",
+	5: "Single-bit ECC",
+	6: "Multi-bit ECC",
+	7: "CRC",
+}
+
+// type 17
+var memoryFormFactors = map[int]string{
+	0x01: "Other",
+	0x02: "Unknown",
+	0x03: "SIMM",
+	0x04: "SIP",
+	0x05: "Chip",
+	0x06: "DIP",
+	0x07: "ZIP",
+	0x08: "Proprietary Card",
+	0x09: "DIMM",
+	0x0A: "TSOP",
+	0x0B: "Row Of Chips",
+	0x0C: "RIMM",
+	0x0D: "SODIMM",
+	0x0E: "SRIMM",
+	0x0F: "FB-DIMM",
+	0x10: "Die",
+	0x11: "CAMM",
+}
+
+var memoryTypes = map[int]string{
+	0x01: "Other",
+	0x02: "Unknown",
+	0x03: "DRAM",
+	0x04: "EDRAM",
+	0x05: "VRAM",
+	0x06: "SRAM",
+	0x07: "RAM",
+	0x08: "ROM",
+	0x09: "Flash",
+	0x0A: "EEPROM",
+	0x0B: "FEPROM",
+	0x0C: "EPROM",
+	0x0D: "CDRAM",
+	0x0E: "3DRAM",
+	0x0F: "SDRAM",
+	0x10: "SGRAM",
+	0x11: "RDRAM",
+	0x12: "DDR",
+	0x13: "DDR2",
+	0x14: "DDR2 FB-DIMM",
+	0x15: "Reserved",
+	0x16: "Reserved",
+	0x17: "Reserved",
+	0x18: "DDR3",
+	0x19: "FBD2",
+	0x1A: "DDR4",
+	0x1B: "LPDDR",
+	0x1C: "LPDDR2",
+	0x1D: "LPDDR3",
+	0x1E: "LPDDR4",
+	0x1F: "Logical non-volatile device",
+	0x20: "HBM",
+	0x21: "HBM2",
+	0x22: "DDR5",
+	0x23: "LPDDR5",
+	0x24: "HBM3",
+}
+
+// 第1位开始
+var memoryTypeDetails = []string{
+	"Other",
+	"Unknown",
+	"Fast-paged",
+	"Static Column",
+	"Pseudo-static",
+	"RAMBus",
+	"Synchronous",
+	"CMOS",
+	"EDO",
+	"Window DRAM",
+	"Cache DRAM",
+	"Non-Volatile",
+	"Registered (Buffered)",
+	"Unbuffered (Unregistered)",
+	"LRDIMM",
+}
+
+// type 22
+var batteryChemistries = map[int]string{
+	0x01: "Other",
+	0x02: "Unknown",
+	0x03: "Lead Acid",
+	0x04: "Nickel Cadmium",
+	0x05: "Nickel Metal Hydride",
+	0x06: "Lithium Ion",
+	0x07: "Zinc Air",
+	0x08: "Lithium Polymer",
+}
+
+// type 26, 28, 29 的 Status, 第5到7位
+var probeStatus = map[int]string{
+	0x01: "Other",
+	0x02: "Unknown",
+	0x03: "OK",
+	0x04: "Non-critical",
+	0x05: "Critical",
+	0x06: "Non-recoverable",
+}
+
+// type 26, 29 的 Location, 第0到4位
+var voltageProbeLocations = map[int]string{
+	0x01: "Other",
+	0x02: "Unknown",
+	0x03: "Processor",
+	0x04: "Disk",
+	0x05: "Peripheral Bay",
+	0x06: "System Management Module",
+	0x07: "Motherboard",
+	0x08: "Memory Module",
+	0x09: "Processor Module",
+	0x0A: "Power Unit",
+	0x0B: "Add-in Card",
+}
+
+// type 27, 第0到4位
+var coolingDeviceTypes = map[int]string{
+	0x01: "Other",
+	0x02: "Unknown",
+	0x03: "Fan",
+	0x04: "Centrifugal Blower",
+	0x05: "Chip Fan",
+	0x06: "Cabinet Fan",
+	0x07: "Power Supply Fan",
+	0x08: "Heat Pipe",
+	0x09: "Integrated Refrigeration",
+	0x10: "Active Cooling",
+	0x11: "Passive Cooling",
+}
+
+// type 28 的 Location, 第0到4位
+var temperatureProbeLocations = map[int]string{
+	0x01: "Other",
+	0x02: "Unknown",
+	0x03: "Processor",
+	0x04: "Disk",
+	0x05: "Peripheral Bay",
+	0x06: "System Management Module",
+	0x07: "Motherboard",
+	0x08: "Memory Module",
+	0x09: "Processor Module",
+	0x0A: "Power Unit",
+	0x0B: "Add-in Card",
+	0x0C: "Front Panel Board",
+	0x0D: "Back Panel Board",
+	0x0E: "Power System Board",
+	0x0F: "Drive Back Plane",
+}
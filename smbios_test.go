@@ -0,0 +1,199 @@
+package dmidecode
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestReadSysfs(t *testing.T) {
+	table, err := ReadSysfs("testdata/sysfs")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if table.EntryPoint.Anchor != "_SM_" || table.EntryPoint.Version() != 0x0207 || table.EntryPoint.StructureCount != 17 {
+		t.Errorf("entry point: %+v", table.EntryPoint)
+	}
+	if len(table.Structures) != 17 {
+		t.Fatalf("structures: got %d, want 17", len(table.Structures))
+	}
+
+	bios, language, _ := table.QueryBIOS()
+	if bios.Vendor != "LENOVO" || bios.Address != "0xE0000" || bios.RuntimeSize != "128 kB" ||
+		bios.RomSize != "8192 kB" || bios.BIOSRevision != "1.76" {
+		t.Errorf("bios: %+v", bios)
+	}
+	if len(bios.Characteristics) != 10 || bios.Characteristics[9] != "UEFI is supported" {
+		t.Errorf("bios characteristics: %q", bios.Characteristics)
+	}
+	if language.LanguageDescriptionFormat != "Abbreviated" || language.InstallableLanguagesNumber != 3 ||
+		language.CurrentlyInstalledLanguage != "en-US" {
+		t.Errorf("language: %+v", language)
+	}
+
+	system, _ := table.QuerySystem()
+	if system.UUID != "3A5F3C81-51E1-11CB-8F39-A8B3CF5D1A8E" || system.WakeUpType != WakeUpPowerSwitch ||
+		system.Family != "ThinkPad T440p" || system.SMBIOSVersion != table.EntryPoint.Version() {
+		t.Errorf("system: %+v", system)
+	}
+
+	board, _ := table.QueryBaseBoard()
+	if board.Type != "Motherboard" || board.ChassisHandle != "0x0003" || len(board.Features) != 2 {
+		t.Errorf("baseboard: %+v", board)
+	}
+
+	chassis, _ := table.QueryChassis()
+	if chassis.Type != ChassisNotebook || chassis.Lock != "Not Present" || chassis.SecurityStatus != "Unknown" ||
+		chassis.OEMInformation != "0x00000000" || chassis.Height != "Unspecified" || chassis.SKUNumber != "" || chassis.Raw["SKU Number"] != "Not Specified" {
+		t.Errorf("chassis: %+v", chassis)
+	}
+
+	processor, _ := table.QueryProcessor()
+	if processor.Family != "Core i7" || processor.Signature != "Type 0, Family 6, Model 60, Stepping 3" ||
+		processor.Voltage != "0.7 V" || processor.MaxSpeed != "2300 MHz" || processor.Status != "Populated, Enabled" ||
+		processor.Upgrade != "Socket rPGA988B" || processor.L3CacheHandle != "0x0008" || processor.ThreadCount != "8" {
+		t.Errorf("processor: %+v", processor)
+	}
+	if len(processor.Characteristics) != 6 || processor.Characteristics[0] != "64-bit capable" {
+		t.Errorf("processor characteristics: %q", processor.Characteristics)
+	}
+
+	memory, _ := table.QueryMemory()
+	if memory.MaximumCapacity != "16 GB" || memory.ErrorInformationHandle != "Not Provided" || len(memory.MemoryList) != 2 {
+		t.Fatalf("memory: %+v", memory)
+	}
+	device := memory.MemoryList[0]
+	if device.Size != "4096 MB" || device.FormFactor != FormFactorSODIMM || device.Type != MemoryTypeDDR3 ||
+		device.TypeDetail != "Synchronous" || device.Speed != "1600 MHz" || device.Rank != "Unknown" {
+		t.Errorf("memory device: %+v", device)
+	}
+	if empty := memory.MemoryList[1]; empty.Size != "No Module Installed" || empty.TotalWidth != "Unknown" ||
+		empty.Manufacturer != "" || empty.Raw["Manufacturer"] != "Not Specified" {
+		t.Errorf("empty memory device: %+v", empty)
+	}
+
+	caches, _ := table.QueryCache()
+	if len(caches) != 3 || caches[2].Configuration != "Enabled, Not Socketed, Level 3" ||
+		caches[2].InstalledSize != "6144 kB" || caches[2].Associativity != Associativity12WaySetAssociative ||
+		caches[2].OperationalMode != "Write Back" || caches[2].InstalledSRAMType != "Synchronous" {
+		t.Errorf("caches: %+v", caches)
+	}
+
+	connectors, _ := table.QueryConnector()
+	if len(connectors) != 2 || connectors[0].ExternalConnectorType != "DB-15 female" || connectors[1].PortType != "Network Port" {
+		t.Errorf("connectors: %+v", connectors)
+	}
+
+	slots, _ := table.QuerySlot()
+	if len(slots) != 1 || slots[0].Type != SlotTypePCIExpress || slots[0].BusWidth != SlotBusWidthX1 || slots[0].ID != "1" ||
+		slots[0].BusAddress != "0000:00:1c.0" || len(slots[0].Characteristics) != 2 {
+		t.Errorf("slots: %+v", slots)
+	}
+}
+
+func TestTable_RecordsMatchText(t *testing.T) {
+	table, err := ReadSysfs("testdata/sysfs")
+	if err != nil {
+		t.Fatal(err)
+	}
+	binary := table.Records()
+	text := ParseRecords(readTestdata(t, "thinkpad.txt"))
+	if len(binary) != len(text) {
+		t.Fatalf("records: got %d, want %d", len(binary), len(text))
+	}
+	for index := range text {
+		if binary[index].Handle != text[index].Handle || binary[index].Type != text[index].Type ||
+			binary[index].Size != text[index].Size || binary[index].Name != text[index].Name {
+			t.Errorf("record %d: got %s type %d %q, want %s type %d %q", index,
+				binary[index].Handle, binary[index].Type, binary[index].Name,
+				text[index].Handle, text[index].Type, text[index].Name)
+		}
+	}
+	// 厂商字符串原样保留, 包括结尾的空格
+	if part := binary[9].Value("Part Number"); part != "HMT451S6AFR8A-PB  " {
+		t.Errorf("part number: %q", part)
+	}
+}
+
+func TestParseEntryPoint_Checksum(t *testing.T) {
+	entry := readTestdata(t, "sysfs/sys/firmware/dmi/tables/smbios_entry_point")
+	broken := []byte(entry)
+	broken[0x06]++
+	if _, err := ParseEntryPoint(broken); err == nil {
+		t.Error("expected checksum error")
+	}
+	if _, err := ParseEntryPoint([]byte("_XX_")); err != ErrEntryPoint {
+		t.Errorf("got %v, want ErrEntryPoint", err)
+	}
+}
+
+// 按SMBIOS 2.1规范中错误的长度0x1E生成的入口点
+func TestParseEntryPoint_Length0x1E(t *testing.T) {
+	data := []byte(readTestdata(t, "sysfs/sys/firmware/dmi/tables/smbios_entry_point"))[:0x1E]
+	data = append([]byte(nil), data...)
+	data[0x05] = 0x1E
+	data[0x04] = 0
+	var sum uint8
+	for _, b := range data {
+		sum += b
+	}
+	data[0x04] = -sum
+	entry, err := ParseEntryPoint(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if entry.Version() != 0x0207 || entry.StructureCount != 17 {
+		t.Errorf("entry point: %+v", entry)
+	}
+}
+
+// 被截断的sysfs文件或dump不能导致panic
+func TestParseEntryPoint_Truncated(t *testing.T) {
+	sm := []byte(readTestdata(t, "sysfs/sys/firmware/dmi/tables/smbios_entry_point"))
+	dump := []byte(readTestdata(t, "dump-3.2.bin"))
+	inputs := [][]byte{[]byte("_SM_"), []byte("_SM_\x00"), []byte("_SM3_"), []byte("_DMI_"), sm[:0x10], sm[:0x1D], dump[:0x10]}
+	for _, data := range inputs {
+		if _, err := ParseEntryPoint(data); err != ErrEntryPoint {
+			t.Errorf("%q: got %v, want ErrEntryPoint", data, err)
+		}
+		if _, err := ParseDump(data); err == nil {
+			t.Errorf("%q: dump expected error", data)
+		}
+	}
+}
+
+func TestParseStructures_BadLength(t *testing.T) {
+	if _, err := ParseStructures([]byte{0, 2, 0, 0, 0, 0}, 0); err == nil {
+		t.Error("expected bad length error")
+	}
+	if _, err := ParseStructures([]byte{127, 4, 1, 0, 'a'}, 0); err == nil {
+		t.Error("expected unterminated strings error")
+	}
+}
+
+func TestFormatSystemUUID(t *testing.T) {
+	raw := []byte{0x81, 0x3C, 0x5F, 0x3A, 0xE1, 0x51, 0xCB, 0x11, 0x8F, 0x39, 0xA8, 0xB3, 0xCF, 0x5D, 0x1A, 0x8E}
+	if got := formatSystemUUID(raw, 0x0205); got != "813C5F3A-E151-CB11-8F39-A8B3CF5D1A8E" {
+		t.Errorf("2.5: got %s", got)
+	}
+	if got := formatSystemUUID(make([]byte, 16), 0x0207); got != "Not Settable" {
+		t.Errorf("zero: got %s", got)
+	}
+	if got := formatSystemUUID(bytes.Repeat([]byte{0xFF}, 16), 0x0207); got != "Not Present" {
+		t.Errorf("ff: got %s", got)
+	}
+}
+
+func TestFormatMemorySize(t *testing.T) {
+	cases := map[uint64]string{
+		16 << 30:          "16 GB",
+		1536 << 20:        "1536 MB",
+		8 << 10:           "8 kB",
+		512:               "512 bytes",
+		(1 << 40) + 1<<30: "1025 GB",
+	}
+	for size, want := range cases {
+		if got := formatMemorySize(size); got != want {
+			t.Errorf("%d: got %s, want %s", size, got, want)
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package dmidecode
+
+import (
+	"context"
+	"errors"
+	"strings"
+)
+
+// sudo -n 执行失败, 需要输入密码
+var ErrSudoPassword = errors.New("dmidecode: sudo requires a password")
+
+// 获取sudo密码的回调, 每次执行命令时调用, 可以从vault等处实时读取
+type CredentialFunc func(ctx context.Context) (string, error)
+
+// 通过sudo执行命令, 密码从子进程的标准输入传入, 不出现在命令行和日志中
+type SudoRunner struct {
+	Sudo       string         // sudo的路径, 为空时使用"sudo"
+	Credential CredentialFunc // 为空时使用 sudo -n 非交互执行
+}
+
+func (r *SudoRunner) Run(ctx context.Context, args []string) ([]byte, error) {
+	if len(args) == 0 {
+		return nil, errNoCommand
+	}
+	sudo := r.Sudo
+	if sudo == "" {
+		sudo = "sudo"
+	}
+	if r.Credential == nil {
+		output, err := runCommand(ctx, append([]string{sudo, "-n", "--"}, args...), nil)
+		return output, sudoError(err)
+	}
+	password, err := r.Credential(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// -S 从标准输入读取密码, -p "" 不输出提示
+	command := append([]string{sudo, "-S", "-p", "", "--"}, args...)
+	return runCommand(ctx, command, []byte(password+"\n"))
+}
+
+// 检查 sudo -n 能否不输入密码执行 path --version
+func (r *SudoRunner) Preflight(ctx context.Context, path string) error {
+	runner := &SudoRunner{Sudo: r.Sudo}
+	_, err := runner.Run(ctx, []string{path, "--version"})
+	return err
+}
+
+// sudo -n 因为需要密码失败时返回ErrSudoPassword
+func sudoError(err error) error {
+	if commandErr, ok := err.(*CommandError); ok && strings.Contains(commandErr.Stderr, "password is required") {
+		return ErrSudoPassword
+	}
+	return err
+}
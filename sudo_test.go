@@ -0,0 +1,93 @@
+package dmidecode
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// 用脚本代替sudo, 输出收到的参数和标准输入
+const fakeSudo = `#!/bin/sh
+if [ "$1" = "-n" ]; then
+	echo "sudo: a password is required" >&2
+	exit 1
+fi
+read password
+echo "args: $*"
+echo "password: $password"
+`
+
+func writeFakeSudo(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "sudo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	path := filepath.Join(dir, "sudo")
+	if err := ioutil.WriteFile(path, []byte(fakeSudo), 0755); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestSudoRunner_Credential(t *testing.T) {
+	password := `p@ss word;$(id)|'"`
+	runner := &SudoRunner{
+		Sudo: writeFakeSudo(t),
+		Credential: func(ctx context.Context) (string, error) {
+			return password, nil
+		},
+	}
+	output, err := runner.Run(context.Background(), []string{"/usr/sbin/dmidecode", "-t", "bios"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "args: -S -p  -- /usr/sbin/dmidecode -t bios\npassword: " + password + "\n"
+	if string(output) != want {
+		t.Errorf("output: got %q, want %q", output, want)
+	}
+}
+
+func TestSudoRunner_CredentialError(t *testing.T) {
+	vaultErr := errors.New("vault sealed")
+	runner := &SudoRunner{
+		Sudo: writeFakeSudo(t),
+		Credential: func(ctx context.Context) (string, error) {
+			return "", vaultErr
+		},
+	}
+	if _, err := runner.Run(context.Background(), []string{"dmidecode"}); err != vaultErr {
+		t.Errorf("got %v, want %v", err, vaultErr)
+	}
+}
+
+func TestSudoRunner_Preflight(t *testing.T) {
+	runner := &SudoRunner{Sudo: writeFakeSudo(t)}
+	if err := runner.Preflight(context.Background(), "/usr/sbin/dmidecode"); err != ErrSudoPassword {
+		t.Errorf("got %v, want ErrSudoPassword", err)
+	}
+}
+
+func TestDmiDecode_SetPassword(t *testing.T) {
+	d := &DmiDecode{Path: "/usr/sbin/dmidecode"}
+	d.SetPassword("secret")
+	if !d.IsActive || d.Path != "/usr/sbin/dmidecode" {
+		t.Errorf("dmidecode: %+v", d)
+	}
+	runner, ok := d.Runner.(*SudoRunner)
+	if !ok {
+		t.Fatalf("runner: %T", d.Runner)
+	}
+	runner.Sudo = writeFakeSudo(t)
+	output, err := d.Runner.Run(context.Background(), []string{d.Path, "-t", "bios"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(output), "password: secret\n") {
+		t.Errorf("output: %q", output)
+	}
+}
@@ -0,0 +1,151 @@
+package dmidecode
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// 带单位的值, 都实现了encoding.TextUnmarshaler, 可以直接用在 `dmi` tag 的字段上.
+// 无法解析的值(例如 Unknown, No Module Installed)保持零值, 不返回错误.
+
+// 大小, 单位字节, 例如 "256 kB", "4096 MB", "16 GB"
+type Size uint64
+
+var sizeUnits = map[string]uint{
+	"bytes": 0,
+	"B":     0,
+	"kB":    10,
+	"KB":    10,
+	"MB":    20,
+	"GB":    30,
+	"TB":    40,
+	"PB":    50,
+}
+
+func (s *Size) UnmarshalText(text []byte) error {
+	*s = 0
+	number, unit, ok := splitUnit(string(text))
+	if !ok {
+		return nil
+	}
+	shift, ok := sizeUnits[unit]
+	if !ok {
+		return nil
+	}
+	// 可能带小数, 例如 "1.5 MB", 用有理数计算避免浮点误差, 不足1字节的部分舍去
+	value, ok := new(big.Rat).SetString(number)
+	if !ok || value.Sign() < 0 || strings.ContainsAny(number, "/eE") {
+		return nil
+	}
+	value.Mul(value, new(big.Rat).SetInt(new(big.Int).Lsh(big.NewInt(1), shift)))
+	bytes := new(big.Int).Quo(value.Num(), value.Denom())
+	if !bytes.IsUint64() {
+		return nil
+	}
+	*s = Size(bytes.Uint64())
+	return nil
+}
+
+// 按dmidecode的习惯输出, 使用能整除的最大单位
+func (s Size) String() string {
+	units := []string{"bytes", "kB", "MB", "GB", "TB", "PB"}
+	value, index := uint64(s), 0
+	for value != 0 && value%1024 == 0 && index < len(units)-1 {
+		value /= 1024
+		index++
+	}
+	return fmt.Sprintf("%d %s", value, units[index])
+}
+
+// 频率, 单位Hz, 例如处理器的 "2300 MHz"
+type Frequency uint64
+
+var frequencyUnits = map[string]uint64{
+	"Hz":  1,
+	"kHz": 1e3,
+	"MHz": 1e6,
+	"GHz": 1e9,
+}
+
+func (f *Frequency) UnmarshalText(text []byte) error {
+	*f = 0
+	number, unit, ok := splitUnit(string(text))
+	if !ok {
+		return nil
+	}
+	scale, ok := frequencyUnits[unit]
+	if !ok {
+		return nil
+	}
+	value, err := strconv.ParseFloat(number, 64)
+	if err != nil || value < 0 {
+		return nil
+	}
+	*f = Frequency(value * float64(scale))
+	return nil
+}
+
+func (f Frequency) MHz() float64 {
+	return float64(f) / 1e6
+}
+
+func (f Frequency) String() string {
+	return strconv.FormatFloat(f.MHz(), 'f', -1, 64) + " MHz"
+}
+
+// 内存的传输速率, 单位MT/s; dmidecode 3.2 之前把同样的数值写成MHz
+type TransferRate uint64
+
+func (r *TransferRate) UnmarshalText(text []byte) error {
+	*r = 0
+	number, unit, ok := splitUnit(string(text))
+	if !ok || (unit != "MT/s" && unit != "MHz") {
+		return nil
+	}
+	if value, err := strconv.ParseUint(number, 10, 64); err == nil {
+		*r = TransferRate(value)
+	}
+	return nil
+}
+
+func (r TransferRate) String() string {
+	return fmt.Sprintf("%d MT/s", uint64(r))
+}
+
+// 电压, 单位V, 例如 "1.2 V", 也支持 "1200 mV"
+type Voltage float64
+
+func (v *Voltage) UnmarshalText(text []byte) error {
+	*v = 0
+	// 旧处理器可能列出多个电压, 例如 "5.0 V 3.3 V", 取第一个
+	fields := strings.Fields(string(text))
+	if len(fields) < 2 {
+		return nil
+	}
+	value, err := strconv.ParseFloat(fields[0], 64)
+	if err != nil {
+		return nil
+	}
+	switch fields[1] {
+	case "V":
+		*v = Voltage(value)
+	case "mV":
+		*v = Voltage(value / 1000)
+	}
+	return nil
+}
+
+func (v Voltage) String() string {
+	return strconv.FormatFloat(float64(v), 'f', -1, 64) + " V"
+}
+
+// 拆分 "4096 MB" 为数字和单位
+func splitUnit(value string) (string, string, bool) {
+	fields := strings.Fields(value)
+	if len(fields) != 2 {
+		return "", "", false
+	}
+	return fields[0], fields[1], true
+}
@@ -0,0 +1,112 @@
+package dmidecode
+
+import "testing"
+
+func TestSize(t *testing.T) {
+	tests := []struct {
+		text string
+		want Size
+		str  string
+	}{
+		{"512 bytes", 512, "512 bytes"},
+		{"256 kB", 256 << 10, "256 kB"},
+		{"8192 KB", 8 << 20, "8 MB"},
+		{"4096 MB", 4 << 30, "4 GB"},
+		{"16 GB", 16 << 30, "16 GB"},
+		{"2 TB", 2 << 40, "2 TB"},
+		{"1.5 MB", 1536 << 10, "1536 kB"},
+		{"0.5 GB", 512 << 20, "512 MB"},
+		{"1.25 kB", 1280, "1280 bytes"},
+		{"0.1 kB", 102, "102 bytes"},
+		{"-1 GB", 0, "0 bytes"},
+		{"1e3 MB", 0, "0 bytes"},
+		{"16777216 TB", 0, "0 bytes"},
+		{"No Module Installed", 0, "0 bytes"},
+		{"Unknown", 0, "0 bytes"},
+		{"12 parsecs", 0, "0 bytes"},
+	}
+	for _, test := range tests {
+		var size Size
+		if err := size.UnmarshalText([]byte(test.text)); err != nil || size != test.want || size.String() != test.str {
+			t.Errorf("%q: got %d %q %v, want %d %q", test.text, size, size, err, test.want, test.str)
+		}
+	}
+}
+
+func TestFrequency(t *testing.T) {
+	tests := []struct {
+		text string
+		want Frequency
+	}{
+		{"2300 MHz", 2300e6},
+		{"100 MHz", 100e6},
+		{"3.5 GHz", 3500e6},
+		{"32768 Hz", 32768},
+		{"Unknown", 0},
+	}
+	for _, test := range tests {
+		var frequency Frequency
+		if frequency.UnmarshalText([]byte(test.text)); frequency != test.want {
+			t.Errorf("%q: got %d, want %d", test.text, frequency, test.want)
+		}
+	}
+	if s := Frequency(2300e6).String(); s != "2300 MHz" {
+		t.Errorf("string: %q", s)
+	}
+}
+
+func TestTransferRate(t *testing.T) {
+	for text, want := range map[string]TransferRate{"1600 MHz": 1600, "2666 MT/s": 2666, "Unknown": 0, "2 GHz": 0} {
+		var rate TransferRate
+		if rate.UnmarshalText([]byte(text)); rate != want {
+			t.Errorf("%q: got %d, want %d", text, rate, want)
+		}
+	}
+}
+
+func TestVoltage(t *testing.T) {
+	for text, want := range map[string]Voltage{"0.7 V": 0.7, "1.2 V": 1.2, "1200 mV": 1.2, "5.0 V 3.3 V": 5, "Unknown": 0} {
+		var voltage Voltage
+		if voltage.UnmarshalText([]byte(text)); voltage != want {
+			t.Errorf("%q: got %v, want %v", text, voltage, want)
+		}
+	}
+}
+
+func TestTypedFields(t *testing.T) {
+	inventory, err := ParseAll(openTestdata(t, "thinkpad.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if inventory.BIOS.RuntimeSizeBytes != 128<<10 || inventory.BIOS.RomSizeBytes != 8<<20 {
+		t.Errorf("bios: %s %s", inventory.BIOS.RuntimeSizeBytes, inventory.BIOS.RomSizeBytes)
+	}
+	processor := inventory.Processors[0]
+	if processor.Volts != 0.7 || processor.ExternalClockHz != 100e6 || processor.MaxSpeedHz != 2300e6 || processor.CurrentSpeedHz != 2300e6 {
+		t.Errorf("processor: %v %s %s %s", processor.Volts, processor.ExternalClockHz, processor.MaxSpeedHz, processor.CurrentSpeedHz)
+	}
+	if processor.Cores != 4 || processor.EnabledCores != 4 || processor.Threads != 8 {
+		t.Errorf("processor: %d %d %d", processor.Cores, processor.EnabledCores, processor.Threads)
+	}
+	if inventory.Memory.MaximumCapacityBytes != 16<<30 || inventory.Memory.DeviceCount != 2 {
+		t.Errorf("memory: %s %d", inventory.Memory.MaximumCapacityBytes, inventory.Memory.DeviceCount)
+	}
+	device := inventory.Memory.MemoryList[0]
+	if device.SizeBytes != 4<<30 || device.SpeedMTs != 1600 || device.ConfiguredSpeedMTs != 1600 ||
+		device.TotalWidthBits != 64 || device.DataWidthBits != 64 || device.Ranks != 0 {
+		t.Errorf("device: %+v", device)
+	}
+	if cache := inventory.Cache[2]; cache.InstalledSizeBytes != 6<<20 || cache.MaximumSizeBytes != 6<<20 {
+		t.Errorf("cache: %s %s", cache.InstalledSizeBytes, cache.MaximumSizeBytes)
+	}
+
+	// dmidecode 3.2 开始使用 MT/s 和 Configured Memory Speed
+	record := Record{Type: 17, Name: "Memory Device", Fields: []Field{
+		{Key: "Speed", Value: "2666 MT/s"},
+		{Key: "Configured Memory Speed", Value: "2400 MT/s"},
+	}}
+	device = memoryDeviceFromRecord(&record)
+	if device.SpeedMTs != 2666 || device.ConfiguredSpeedMTs != 2400 || device.ConfiguredClockSpeed != "2400 MT/s" || device.Extra != nil {
+		t.Errorf("device: %+v", device)
+	}
+}
@@ -0,0 +1,101 @@
+package dmidecode
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// System Information中的UUID, 按RFC 4122的顺序保存, 与product_uuid和libvirt显示的一致
+type UUID [16]byte
+
+// 很多主板共用的占位UUID, 不能用来区分机器
+var placeholderUUIDs = map[UUID]bool{
+	// AMI BIOS的默认值
+	{0x03, 0x00, 0x02, 0x00, 0x04, 0x00, 0x05, 0x00, 0x00, 0x06, 0x00, 0x07, 0x00, 0x08, 0x00, 0x09}: true,
+}
+
+// 解析文本形式的UUID, 大小写均可, 可以省略'-'或带有花括号
+func ParseUUID(value string) (UUID, error) {
+	var result UUID
+	text := strings.TrimSpace(value)
+	text = strings.TrimSuffix(strings.TrimPrefix(text, "{"), "}")
+	text = strings.Replace(text, "-", "", -1)
+	if len(text) != 32 {
+		return result, fmt.Errorf("dmidecode: malformed UUID %q", value)
+	}
+	if _, err := hex.Decode(result[:], []byte(text)); err != nil {
+		return result, fmt.Errorf("dmidecode: malformed UUID %q", value)
+	}
+	return result, nil
+}
+
+// 从SMBIOS表中的16个字节得到UUID, version为SMBIOS版本(例如0x0207).
+// 2.6及以后的版本前三段按小端存储, 之前的版本按网络字节序存储
+func UUIDFromBytes(p []byte, version int) (UUID, error) {
+	var result UUID
+	if len(p) < 16 {
+		return result, fmt.Errorf("dmidecode: UUID needs 16 bytes, got %d", len(p))
+	}
+	copy(result[:], p[:16])
+	if version >= 0x0206 {
+		result[0], result[1], result[2], result[3] = p[3], p[2], p[1], p[0]
+		result[4], result[5] = p[5], p[4]
+		result[6], result[7] = p[7], p[6]
+	}
+	return result, nil
+}
+
+// 转换为SMBIOS表中的16个字节, 是UUIDFromBytes的逆操作
+func (u UUID) Bytes(version int) []byte {
+	result := make([]byte, 16)
+	copy(result, u[:])
+	if version >= 0x0206 {
+		result[0], result[1], result[2], result[3] = u[3], u[2], u[1], u[0]
+		result[4], result[5] = u[5], u[4]
+		result[6], result[7] = u[7], u[6]
+	}
+	return result
+}
+
+// dmidecode把全部为0xFF的UUID显示为 "Not Present", 全部为0的显示为 "Not Settable",
+// 其他无法解析的值为零值
+func (u *UUID) UnmarshalText(text []byte) error {
+	switch value := strings.TrimSpace(string(text)); value {
+	case "Not Present":
+		for index := range u {
+			u[index] = 0xFF
+		}
+	default:
+		*u, _ = ParseUUID(value)
+	}
+	return nil
+}
+
+// 全部为0
+func (u UUID) IsZero() bool {
+	return u == UUID{}
+}
+
+// 全部为0, 全部为0xFF, 或者是已知的占位UUID
+func (u UUID) IsPlaceholder() bool {
+	if u.IsZero() || placeholderUUIDs[u] {
+		return true
+	}
+	for _, b := range u {
+		if b != 0xFF {
+			return false
+		}
+	}
+	return true
+}
+
+// 是否可以用来标识机器
+func (u UUID) Valid() bool {
+	return !u.IsPlaceholder()
+}
+
+// 小写, 与 /sys/class/dmi/id/product_uuid 和libvirt一致
+func (u UUID) String() string {
+	return fmt.Sprintf("%x-%x-%x-%x-%x", u[0:4], u[4:6], u[6:8], u[8:10], u[10:16])
+}
@@ -0,0 +1,75 @@
+package dmidecode
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestParseUUID(t *testing.T) {
+	want := "3a5f3c81-51e1-11cb-8f39-a8b3cf5d1a8e"
+	for _, text := range []string{"3A5F3C81-51E1-11CB-8F39-A8B3CF5D1A8E", want, "{3a5f3c81-51e1-11cb-8f39-a8b3cf5d1a8e}", "3A5F3C8151E111CB8F39A8B3CF5D1A8E"} {
+		uuid, err := ParseUUID(text)
+		if err != nil || uuid.String() != want || !uuid.Valid() {
+			t.Errorf("%q: got %v %v", text, uuid, err)
+		}
+	}
+	for _, text := range []string{"", "Not Settable", "3A5F3C81-51E1-11CB-8F39", "ZZ5F3C81-51E1-11CB-8F39-A8B3CF5D1A8E"} {
+		if _, err := ParseUUID(text); err == nil {
+			t.Errorf("%q: expected error", text)
+		}
+	}
+}
+
+func TestUUIDFromBytes(t *testing.T) {
+	raw := []byte{0x81, 0x3C, 0x5F, 0x3A, 0xE1, 0x51, 0xCB, 0x11, 0x8F, 0x39, 0xA8, 0xB3, 0xCF, 0x5D, 0x1A, 0x8E}
+	uuid, err := UUIDFromBytes(raw, 0x0207)
+	if err != nil || uuid.String() != "3a5f3c81-51e1-11cb-8f39-a8b3cf5d1a8e" {
+		t.Errorf("2.7: got %v %v", uuid, err)
+	}
+	if !bytes.Equal(uuid.Bytes(0x0207), raw) {
+		t.Errorf("bytes: %x", uuid.Bytes(0x0207))
+	}
+	// 2.6之前不交换字节序
+	old, _ := UUIDFromBytes(raw, 0x0205)
+	if old.String() != "813c5f3a-e151-cb11-8f39-a8b3cf5d1a8e" || !bytes.Equal(old.Bytes(0x0205), raw) {
+		t.Errorf("2.5: got %v", old)
+	}
+	if _, err := UUIDFromBytes(raw[:8], 0x0207); err == nil {
+		t.Error("short: expected error")
+	}
+}
+
+func TestUUID_Placeholder(t *testing.T) {
+	for _, text := range []string{"Not Present", "Not Settable", "00000000-0000-0000-0000-000000000000",
+		"FFFFFFFF-FFFF-FFFF-FFFF-FFFFFFFFFFFF", "03000200-0400-0500-0006-000700080009", "garbage"} {
+		var uuid UUID
+		uuid.UnmarshalText([]byte(text))
+		if uuid.Valid() {
+			t.Errorf("%q: got valid %v", text, uuid)
+		}
+	}
+
+	system := systemFromRecords(ParseRecords(readTestdata(t, "thinkpad.txt")))
+	if system.SystemUUID.String() != "3a5f3c81-51e1-11cb-8f39-a8b3cf5d1a8e" || !system.SystemUUID.Valid() {
+		t.Errorf("system: %v", system.SystemUUID)
+	}
+}
+
+func TestSystemInfo_SMBIOSVersion(t *testing.T) {
+	system := systemFromRecords(ParseRecords(readTestdata(t, "thinkpad.txt")))
+	if system.SMBIOSVersion != 0x0207 {
+		t.Fatalf("version: %#x", system.SMBIOSVersion)
+	}
+	// 2.7按小端存储前三段
+	raw := system.SystemUUID.Bytes(system.SMBIOSVersion)
+	if raw[0] != 0x81 || raw[3] != 0x3A || raw[8] != 0x8F {
+		t.Errorf("bytes: % x", raw)
+	}
+	if uuid, _ := UUIDFromBytes(raw, system.SMBIOSVersion); uuid != system.SystemUUID {
+		t.Errorf("round trip: %v", uuid)
+	}
+	// dmidecode -q 的输出没有版本
+	if system := systemFromRecords(ParseRecords("System Information\n\tUUID: 3A5F3C81-51E1-11CB-8F39-A8B3CF5D1A8E\n")); system.SMBIOSVersion != 0 {
+		t.Errorf("quiet: %#x", system.SMBIOSVersion)
+	}
+}